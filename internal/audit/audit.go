@@ -0,0 +1,186 @@
+// Package audit records the mutating actions performed through the Jenkins
+// MCP Server. Jenkins only sees the server's service account, so every
+// mutating tool call is written as an audit event that names the MCP session
+// and client behind it, its arguments and its outcome. Audit events are kept
+// apart from the server's log and go to a JSON lines file, a webhook, or both.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+// Outcomes of an audited tool call
+const (
+	// OutcomeSuccess means the action was performed
+	OutcomeSuccess = "success"
+	// OutcomeError means the action was attempted and failed
+	OutcomeError = "error"
+	// OutcomeDenied means the tool policy rejected the call
+	OutcomeDenied = "denied"
+	// OutcomeUnconfirmed means the call was declined or carried an invalid confirm token
+	OutcomeUnconfirmed = "unconfirmed"
+	// OutcomeConfirmationRequired means a confirm token was issued and nothing was performed yet
+	OutcomeConfirmationRequired = "confirmation_required"
+)
+
+// Event describes a single mutating tool call
+type Event struct {
+	Time       time.Time       `json:"time"`
+	Tool       string          `json:"tool"`
+	Instance   string          `json:"instance,omitempty"`
+	Session    Session         `json:"session"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	Outcome    string          `json:"outcome"`
+	ErrorCode  string          `json:"errorCode,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	QueueID    int             `json:"queueId,omitempty"`
+	DurationMs int64           `json:"durationMs"`
+	TraceID    string          `json:"traceId,omitempty"`
+}
+
+// Session identifies the MCP client that made a call
+type Session struct {
+	ID            string `json:"id,omitempty"`
+	ClientName    string `json:"clientName,omitempty"`
+	ClientVersion string `json:"clientVersion,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+}
+
+// sink is a destination for encoded audit events
+type sink interface {
+	write(ctx context.Context, line []byte) error
+	Close() error
+}
+
+// Logger writes audit events to every configured sink. A nil Logger
+// discards events, so callers do not need to check whether auditing is
+// enabled.
+type Logger struct {
+	sinks []sink
+}
+
+// New creates a Logger for the configured sinks, or returns nil when no sink is configured
+func New(cfg config.AuditConfig) (*Logger, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	l := &Logger{}
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit file: %w", err)
+		}
+		l.sinks = append(l.sinks, &fileSink{file: f})
+	}
+	if cfg.WebhookURL != "" {
+		l.sinks = append(l.sinks, &webhookSink{
+			url:    cfg.WebhookURL,
+			token:  cfg.WebhookToken,
+			client: &http.Client{Timeout: cfg.WebhookTimeout},
+		})
+	}
+
+	return l, nil
+}
+
+// Record writes an event to every sink. All sinks are attempted even when one
+// of them fails; the returned error joins their failures.
+func (l *Logger) Record(ctx context.Context, event Event) error {
+	if l == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	var errs []error
+	for _, s := range l.sinks {
+		if err := s.write(ctx, line); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the audit file
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fileSink appends one JSON document per line to a file
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (s *fileSink) write(_ context.Context, line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit file: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// webhookSink posts each event as a JSON document to a URL
+type webhookSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (s *webhookSink) write(ctx context.Context, line []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(line))
+	if err != nil {
+		return fmt.Errorf("failed to create audit webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit event: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	return nil
+}
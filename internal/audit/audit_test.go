@@ -0,0 +1,143 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+func TestNewDisabled(t *testing.T) {
+	l, err := New(config.AuditConfig{})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if l != nil {
+		t.Fatal("New() returned a logger although no sink is configured")
+	}
+
+	// A nil logger discards events
+	if err := l.Record(context.Background(), Event{Tool: "jenkins_stop_build"}); err != nil {
+		t.Errorf("Record() on nil logger failed: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Close() on nil logger failed: %v", err)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := New(config.AuditConfig{File: path})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	events := []Event{
+		{Tool: "jenkins_trigger_build", Outcome: OutcomeSuccess, QueueID: 42, Arguments: json.RawMessage(`{"jobName":"app"}`)},
+		{Tool: "jenkins_stop_build", Outcome: OutcomeError, ErrorCode: "NOT_FOUND", Session: Session{ID: "s1", ClientName: "test-client"}},
+	}
+	for _, event := range events {
+		if err := l.Record(context.Background(), event); err != nil {
+			t.Fatalf("Record() failed: %v", err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit file: %v", err)
+	}
+	defer f.Close()
+
+	var got []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("audit line %q is not JSON: %v", scanner.Text(), err)
+		}
+		got = append(got, event)
+	}
+
+	if len(got) != len(events) {
+		t.Fatalf("audit file has %d lines, want %d", len(got), len(events))
+	}
+	if got[0].QueueID != 42 || string(got[0].Arguments) != `{"jobName":"app"}` {
+		t.Errorf("first event = %+v, want queue ID 42 and the arguments", got[0])
+	}
+	if got[1].Session.ClientName != "test-client" || got[1].ErrorCode != "NOT_FOUND" {
+		t.Errorf("second event = %+v, want the session and error code", got[1])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("audit file mode = %v, want 0600", perm)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received Event
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	l, err := New(config.AuditConfig{WebhookURL: server.URL, WebhookToken: "secret", WebhookTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if err := l.Record(context.Background(), Event{Tool: "jenkins_cancel_queue_item", Outcome: OutcomeSuccess}); err != nil {
+		t.Fatalf("Record() failed: %v", err)
+	}
+	if received.Tool != "jenkins_cancel_queue_item" || received.Outcome != OutcomeSuccess {
+		t.Errorf("webhook received %+v", received)
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want bearer token", authorization)
+	}
+}
+
+func TestWebhookSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := New(config.AuditConfig{File: path, WebhookURL: server.URL, WebhookTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer l.Close()
+
+	if err := l.Record(context.Background(), Event{Tool: "jenkins_stop_build"}); err == nil {
+		t.Error("Record() succeeded, want the webhook error")
+	}
+
+	// The file sink still received the event
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit file: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("audit file is empty although only the webhook failed")
+	}
+}
@@ -5,11 +5,94 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Supported MCP transport modes
+const (
+	TransportStdio          = "stdio"
+	TransportSSE            = "sse"
+	TransportStreamableHTTP = "http"
+)
+
+// Supported confirmation modes for destructive tools
+const (
+	// ConfirmModeOff runs tools without asking for confirmation
+	ConfirmModeOff = "off"
+	// ConfirmModeAuto uses elicitation when the client supports it and a confirm token otherwise
+	ConfirmModeAuto = "auto"
+	// ConfirmModeElicit requires the client to support elicitation
+	ConfirmModeElicit = "elicit"
+	// ConfirmModeToken always uses a two-phase confirm token
+	ConfirmModeToken = "token"
+)
+
+// DefaultConfirmTools are the tools that require confirmation unless configured otherwise
+var DefaultConfirmTools = []string{
+	"jenkins_trigger_build",
+	"jenkins_rebuild",
+	"jenkins_stop_build",
+	"jenkins_cancel_queue_item",
+	"jenkins_update_job_config",
+	"jenkins_disable_job",
+	"jenkins_rename_job",
+	"jenkins_delete_job",
+	"jenkins_take_node_offline",
+	"jenkins_bring_node_online",
+}
+
+// Endpoint classes of the Jenkins response cache, each with its own TTL
+const (
+	CacheEndpointJob        = "job"
+	CacheEndpointBuild      = "build"
+	CacheEndpointQueue      = "queue"
+	CacheEndpointNode       = "node"
+	CacheEndpointView       = "view"
+	CacheEndpointConfig     = "config"
+	CacheEndpointPipeline   = "pipeline"
+	CacheEndpointTestReport = "testreport"
+	CacheEndpointLog        = "log"
+	CacheEndpointArtifact   = "artifact"
+)
+
+// DefaultCacheTTLs are the response cache TTLs used for endpoint classes that
+// are not configured. Classes with a zero TTL are only cached when Jenkins
+// sends an ETag or Last-Modified header, and are revalidated on every read.
+var DefaultCacheTTLs = map[string]time.Duration{
+	CacheEndpointJob:        30 * time.Second,
+	CacheEndpointBuild:      5 * time.Minute,
+	CacheEndpointQueue:      0,
+	CacheEndpointNode:       10 * time.Second,
+	CacheEndpointView:       time.Minute,
+	CacheEndpointConfig:     30 * time.Second,
+	CacheEndpointPipeline:   0,
+	CacheEndpointTestReport: 0,
+	CacheEndpointLog:        0,
+	CacheEndpointArtifact:   0,
+}
+
+// Default response cache limits
+const (
+	DefaultCacheMaxEntries   = 1000
+	DefaultCacheMaxEntrySize = 1 << 20
+)
+
+// Supported trace exporters and OTLP protocols
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingProtocolGRPC   = "grpc"
+	TracingProtocolHTTP   = "http"
+)
+
+// DefaultInstanceName names the instance configured by the top-level jenkins section
+const DefaultInstanceName = "default"
+
 // Config holds the configuration for the Jenkins MCP Server
 type Config struct {
 	JenkinsURL    string
@@ -21,6 +104,152 @@ type Config struct {
 	CACertPath    string
 	MaxRetries    int
 	RetryBackoff  time.Duration
+
+	// InstanceName names the Jenkins instance configured above
+	InstanceName string
+	// Instances lists additional named Jenkins instances
+	Instances []InstanceConfig
+
+	// MCP transport settings
+	Transport       string
+	ListenAddr      string
+	ShutdownTimeout time.Duration
+
+	// Tool access policy
+	Policy PolicyConfig
+
+	// Confirmation of destructive tools
+	Confirmation ConfirmationConfig
+
+	// In-memory cache of Jenkins read responses
+	Cache CacheConfig
+
+	// Prometheus metrics listener
+	Metrics MetricsConfig
+
+	// OpenTelemetry tracing
+	Tracing TracingConfig
+
+	// Audit log of mutating tool calls
+	Audit AuditConfig
+
+	// Masking of secrets in logs and tool output
+	Redaction RedactionConfig
+}
+
+// InstanceConfig describes an additional named Jenkins instance.
+// Zero timeout and retry settings inherit the values of the default instance.
+type InstanceConfig struct {
+	Name        string        `mapstructure:"name"`
+	Description string        `mapstructure:"description"`
+	JenkinsURL  string        `mapstructure:"url"`
+	Username    string        `mapstructure:"username"`
+	Password    string        `mapstructure:"password"`
+	APIToken    string        `mapstructure:"apiToken"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+	TLS         InstanceTLS   `mapstructure:"tls"`
+	Retry       InstanceRetry `mapstructure:"retry"`
+}
+
+// InstanceTLS holds the TLS settings of a named instance
+type InstanceTLS struct {
+	SkipVerify bool   `mapstructure:"skipVerify"`
+	CACert     string `mapstructure:"caCert"`
+}
+
+// InstanceRetry holds the retry settings of a named instance
+type InstanceRetry struct {
+	MaxAttempts int           `mapstructure:"maxAttempts"`
+	Backoff     time.Duration `mapstructure:"backoff"`
+}
+
+// PolicyConfig controls which MCP tools are exposed and which jobs they may act on
+type PolicyConfig struct {
+	// ReadOnly exposes only tools that do not modify Jenkins
+	ReadOnly bool
+	// AllowedTools, when non-empty, limits the exposed tools to those matching one of these glob patterns
+	AllowedTools []string
+	// DeniedTools hides tools matching any of these glob patterns; it takes precedence over AllowedTools
+	DeniedTools []string
+	// JobRestrictions maps a tool name pattern to the job name globs that tool may act on
+	JobRestrictions map[string][]string
+}
+
+// ConfirmationConfig controls which tools require explicit confirmation before they run
+type ConfirmationConfig struct {
+	// Mode selects how confirmation is obtained (off, auto, elicit or token)
+	Mode string
+	// Tools lists glob patterns of the tools that require confirmation
+	Tools []string
+	// TokenTTL is how long a confirm token stays valid
+	TokenTTL time.Duration
+}
+
+// CacheConfig controls the client-side cache of Jenkins GET responses
+type CacheConfig struct {
+	// Enabled turns the response cache on
+	Enabled bool
+	// TTLs maps an endpoint class to how long its responses are served without asking Jenkins
+	TTLs map[string]time.Duration
+	// MaxEntries bounds the number of cached responses; the least recently used are evicted
+	MaxEntries int
+	// MaxEntrySize bounds the size in bytes of a single cached response
+	MaxEntrySize int64
+}
+
+// MetricsConfig controls the Prometheus metrics listener
+type MetricsConfig struct {
+	// Enabled starts the metrics listener
+	Enabled bool
+	// ListenAddr is the address the metrics listener binds to
+	ListenAddr string
+	// Path is the URL path metrics are served at
+	Path string
+}
+
+// TracingConfig controls OpenTelemetry tracing of tool calls and Jenkins requests
+type TracingConfig struct {
+	// Enabled turns tracing on
+	Enabled bool
+	// Exporter selects where spans are sent (otlp or stdout)
+	Exporter string
+	// Endpoint is the OTLP collector address, as host:port or URL. When empty
+	// the standard OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string
+	// Protocol selects the OTLP protocol (grpc or http)
+	Protocol string
+	// Insecure disables TLS for the OTLP connection
+	Insecure bool
+	// SampleRatio is the fraction of tool calls that are traced
+	SampleRatio float64
+}
+
+// AuditConfig controls the audit log of mutating tool calls. The audit log is
+// written when a file or a webhook URL is configured.
+type AuditConfig struct {
+	// File is the path of a JSON lines file audit events are appended to
+	File string
+	// WebhookURL receives every audit event as a JSON POST request
+	WebhookURL string
+	// WebhookToken, when set, is sent to the webhook as a bearer token
+	WebhookToken string
+	// WebhookTimeout bounds each webhook request
+	WebhookTimeout time.Duration
+}
+
+// Enabled reports whether an audit sink is configured
+func (c *AuditConfig) Enabled() bool {
+	return c.File != "" || c.WebhookURL != ""
+}
+
+// RedactionConfig controls the masking of secrets in the server's logs, the
+// audit log and the build logs and artifacts returned by tools
+type RedactionConfig struct {
+	// Enabled turns redaction on
+	Enabled bool
+	// Patterns are additional regular expressions whose matches are masked.
+	// When a pattern has a capturing group only the first group is masked.
+	Patterns []string
 }
 
 // Validate validates the configuration values
@@ -61,6 +290,147 @@ func (c *Config) Validate() error {
 		return errors.New("retry backoff must be non-negative")
 	}
 
+	// Validate transport settings
+	if err := c.ValidateTransport(); err != nil {
+		return err
+	}
+
+	// Validate policy patterns
+	if err := c.Policy.Validate(); err != nil {
+		return fmt.Errorf("invalid policy: %w", err)
+	}
+
+	// Validate confirmation settings
+	if err := c.Confirmation.Validate(); err != nil {
+		return fmt.Errorf("invalid confirmation settings: %w", err)
+	}
+
+	// Validate cache settings
+	if err := c.Cache.Validate(); err != nil {
+		return fmt.Errorf("invalid cache settings: %w", err)
+	}
+
+	// Validate metrics settings
+	if err := c.ValidateMetrics(); err != nil {
+		return fmt.Errorf("invalid metrics settings: %w", err)
+	}
+
+	// Validate tracing settings
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing settings: %w", err)
+	}
+
+	// Validate audit settings
+	if err := c.Audit.Validate(); err != nil {
+		return fmt.Errorf("invalid audit settings: %w", err)
+	}
+
+	// Validate redaction patterns
+	if err := c.Redaction.Validate(); err != nil {
+		return fmt.Errorf("invalid redaction settings: %w", err)
+	}
+
+	// Validate named instances
+	if err := c.ValidateInstances(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateInstances checks that instance names are unique and that every
+// named instance has a valid connection configuration
+func (c *Config) ValidateInstances() error {
+	seen := map[string]bool{c.DefaultInstance(): true}
+	for _, inst := range c.Instances {
+		if inst.Name == "" {
+			return errors.New("instance name cannot be empty")
+		}
+		if seen[inst.Name] {
+			return fmt.Errorf("duplicate instance name %q", inst.Name)
+		}
+		seen[inst.Name] = true
+
+		if err := c.ForInstance(inst).Validate(); err != nil {
+			return fmt.Errorf("invalid instance %q: %w", inst.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultInstance returns the name of the instance configured by the top-level jenkins section
+func (c *Config) DefaultInstance() string {
+	if c.InstanceName == "" {
+		return DefaultInstanceName
+	}
+	return c.InstanceName
+}
+
+// ForInstance returns a copy of the configuration that connects to the given
+// named instance instead of the default one
+func (c *Config) ForInstance(inst InstanceConfig) *Config {
+	cfg := *c
+	cfg.InstanceName = inst.Name
+	cfg.Instances = nil
+	cfg.JenkinsURL = inst.JenkinsURL
+	cfg.Username = inst.Username
+	cfg.Password = inst.Password
+	cfg.APIToken = inst.APIToken
+	cfg.TLSSkipVerify = inst.TLS.SkipVerify
+	cfg.CACertPath = inst.TLS.CACert
+
+	if inst.Timeout != 0 {
+		cfg.Timeout = inst.Timeout
+	}
+	if inst.Retry.MaxAttempts != 0 {
+		cfg.MaxRetries = inst.Retry.MaxAttempts
+	}
+	if inst.Retry.Backoff != 0 {
+		cfg.RetryBackoff = inst.Retry.Backoff
+	}
+
+	return &cfg
+}
+
+// ValidateTransport validates the MCP transport mode and its listener settings
+func (c *Config) ValidateTransport() error {
+	switch strings.ToLower(c.Transport) {
+	case "", TransportStdio:
+		return nil
+	case TransportSSE, TransportStreamableHTTP:
+		if c.ListenAddr == "" {
+			return fmt.Errorf("listen address is required for %s transport", c.Transport)
+		}
+		if c.ShutdownTimeout < 0 {
+			return errors.New("shutdown timeout must be non-negative")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported transport %q: must be one of %s, %s, %s",
+			c.Transport, TransportStdio, TransportSSE, TransportStreamableHTTP)
+	}
+}
+
+// ValidateMetrics checks the metrics listener settings when metrics are enabled
+func (c *Config) ValidateMetrics() error {
+	if !c.Metrics.Enabled {
+		return nil
+	}
+
+	if c.Metrics.ListenAddr == "" {
+		return errors.New("listen address is required")
+	}
+
+	if !strings.HasPrefix(c.Metrics.Path, "/") {
+		return fmt.Errorf("path %q must start with /", c.Metrics.Path)
+	}
+
+	// The MCP HTTP transports own their listen address
+	if c.Metrics.ListenAddr == c.ListenAddr && (c.Transport == TransportSSE || c.Transport == TransportStreamableHTTP) {
+		return fmt.Errorf("listen address %s is already used by the %s transport", c.ListenAddr, c.Transport)
+	}
+
 	return nil
 }
 
@@ -115,6 +485,130 @@ func (c *Config) ValidateTimeout() error {
 	return nil
 }
 
+// Validate checks that all tool and job patterns in the policy are well-formed globs
+func (p *PolicyConfig) Validate() error {
+	patterns := append(append([]string{}, p.AllowedTools...), p.DeniedTools...)
+	for tool, jobs := range p.JobRestrictions {
+		patterns = append(patterns, tool)
+		for _, job := range jobs {
+			patterns = append(patterns, strings.TrimSuffix(job, "/**"))
+		}
+	}
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the confirmation mode, tool patterns and token lifetime
+func (c *ConfirmationConfig) Validate() error {
+	switch strings.ToLower(c.Mode) {
+	case "", ConfirmModeOff, ConfirmModeAuto, ConfirmModeElicit, ConfirmModeToken:
+	default:
+		return fmt.Errorf("unsupported mode %q: must be one of %s, %s, %s, %s",
+			c.Mode, ConfirmModeOff, ConfirmModeAuto, ConfirmModeElicit, ConfirmModeToken)
+	}
+
+	for _, pattern := range c.Tools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad pattern %q: %w", pattern, err)
+		}
+	}
+
+	if c.TokenTTL < 0 {
+		return errors.New("token TTL must be non-negative")
+	}
+
+	return nil
+}
+
+// Validate checks the cache limits and that every TTL names a known endpoint class
+func (c *CacheConfig) Validate() error {
+	for endpoint, ttl := range c.TTLs {
+		if _, ok := DefaultCacheTTLs[endpoint]; !ok {
+			return fmt.Errorf("unknown endpoint %q in TTLs", endpoint)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("TTL of %s must be non-negative", endpoint)
+		}
+	}
+
+	if c.MaxEntries < 0 {
+		return errors.New("max entries must be non-negative")
+	}
+
+	if c.MaxEntrySize < 0 {
+		return errors.New("max entry size must be non-negative")
+	}
+
+	return nil
+}
+
+// Validate checks the exporter, protocol and sample ratio when tracing is enabled
+func (c *TracingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch strings.ToLower(c.Exporter) {
+	case "", TracingExporterOTLP, TracingExporterStdout:
+	default:
+		return fmt.Errorf("unsupported exporter %q: must be one of %s, %s",
+			c.Exporter, TracingExporterOTLP, TracingExporterStdout)
+	}
+
+	switch strings.ToLower(c.Protocol) {
+	case "", TracingProtocolGRPC, TracingProtocolHTTP:
+	default:
+		return fmt.Errorf("unsupported protocol %q: must be one of %s, %s",
+			c.Protocol, TracingProtocolGRPC, TracingProtocolHTTP)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("sample ratio %v must be between 0 and 1", c.SampleRatio)
+	}
+
+	return nil
+}
+
+// Validate checks the audit webhook settings
+func (c *AuditConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(c.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("webhook URL must use http or https scheme")
+	}
+	if u.Host == "" {
+		return errors.New("webhook URL must include a host")
+	}
+
+	if c.WebhookTimeout <= 0 {
+		return errors.New("webhook timeout must be positive")
+	}
+
+	return nil
+}
+
+// Validate checks that the redaction patterns are valid regular expressions
+func (c *RedactionConfig) Validate() error {
+	for _, pattern := range c.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
 // Load loads configuration from environment variables or configuration file
 // Configuration priority: defaults < config file < environment variables
 func Load() (*Config, error) {
@@ -151,6 +645,78 @@ func Load() (*Config, error) {
 		CACertPath:    v.GetString("jenkins.tls.caCert"),
 		MaxRetries:    v.GetInt("jenkins.retry.maxAttempts"),
 		RetryBackoff:  v.GetDuration("jenkins.retry.backoff"),
+		InstanceName:  v.GetString("jenkins.name"),
+
+		Transport:       strings.ToLower(v.GetString("server.transport")),
+		ListenAddr:      v.GetString("server.listenAddr"),
+		ShutdownTimeout: v.GetDuration("server.shutdownTimeout"),
+
+		Policy: PolicyConfig{
+			ReadOnly:        v.GetBool("policy.readOnly"),
+			AllowedTools:    getStringList(v, "policy.allowTools"),
+			DeniedTools:     getStringList(v, "policy.denyTools"),
+			JobRestrictions: v.GetStringMapStringSlice("policy.jobRestrictions"),
+		},
+
+		Confirmation: ConfirmationConfig{
+			Mode:     strings.ToLower(v.GetString("confirmation.mode")),
+			Tools:    getStringList(v, "confirmation.tools"),
+			TokenTTL: v.GetDuration("confirmation.tokenTTL"),
+		},
+
+		Cache: CacheConfig{
+			Enabled:      v.GetBool("cache.enabled"),
+			MaxEntries:   v.GetInt("cache.maxEntries"),
+			MaxEntrySize: v.GetInt64("cache.maxEntrySize"),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled:    v.GetBool("metrics.enabled"),
+			ListenAddr: v.GetString("metrics.listenAddr"),
+			Path:       v.GetString("metrics.path"),
+		},
+
+		Tracing: TracingConfig{
+			Enabled:     v.GetBool("tracing.enabled"),
+			Exporter:    strings.ToLower(v.GetString("tracing.exporter")),
+			Endpoint:    v.GetString("tracing.endpoint"),
+			Protocol:    strings.ToLower(v.GetString("tracing.protocol")),
+			Insecure:    v.GetBool("tracing.insecure"),
+			SampleRatio: v.GetFloat64("tracing.sampleRatio"),
+		},
+
+		Audit: AuditConfig{
+			File:           v.GetString("audit.file"),
+			WebhookURL:     v.GetString("audit.webhookURL"),
+			WebhookToken:   v.GetString("audit.webhookToken"),
+			WebhookTimeout: v.GetDuration("audit.webhookTimeout"),
+		},
+
+		Redaction: RedactionConfig{
+			Enabled:  v.GetBool("redaction.enabled"),
+			Patterns: getPatternList(v, "redaction.patterns"),
+		},
+	}
+
+	// Load cache TTLs over the defaults
+	ttls, err := getDurationMap(v, "cache.ttls")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache TTLs: %w", err)
+	}
+	cfg.Cache.TTLs = make(map[string]time.Duration, len(DefaultCacheTTLs))
+	for endpoint, ttl := range DefaultCacheTTLs {
+		cfg.Cache.TTLs[endpoint] = ttl
+	}
+	for endpoint, ttl := range ttls {
+		cfg.Cache.TTLs[endpoint] = ttl
+	}
+
+	// Load named instances
+	if err := v.UnmarshalKey("instances", &cfg.Instances); err != nil {
+		return nil, fmt.Errorf("failed to parse instances: %w", err)
+	}
+	for i := range cfg.Instances {
+		applyInstanceEnv(&cfg.Instances[i])
 	}
 
 	// Validate configuration
@@ -167,6 +733,24 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("jenkins.tls.skipVerify", false)
 	v.SetDefault("jenkins.retry.maxAttempts", 3)
 	v.SetDefault("jenkins.retry.backoff", 1*time.Second)
+	v.SetDefault("server.transport", TransportStdio)
+	v.SetDefault("server.listenAddr", ":8080")
+	v.SetDefault("server.shutdownTimeout", 10*time.Second)
+	v.SetDefault("confirmation.mode", ConfirmModeAuto)
+	v.SetDefault("confirmation.tools", DefaultConfirmTools)
+	v.SetDefault("confirmation.tokenTTL", 5*time.Minute)
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.maxEntries", DefaultCacheMaxEntries)
+	v.SetDefault("cache.maxEntrySize", DefaultCacheMaxEntrySize)
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.listenAddr", ":9090")
+	v.SetDefault("metrics.path", "/metrics")
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.exporter", TracingExporterOTLP)
+	v.SetDefault("tracing.protocol", TracingProtocolGRPC)
+	v.SetDefault("tracing.sampleRatio", 1.0)
+	v.SetDefault("audit.webhookTimeout", 5*time.Second)
+	v.SetDefault("redaction.enabled", true)
 }
 
 // bindEnvVariables binds environment variables to configuration keys
@@ -176,15 +760,44 @@ func bindEnvVariables(v *viper.Viper) {
 
 	// Bind specific environment variables to config keys
 	envBindings := map[string]string{
-		"JENKINS_URL":             "jenkins.url",
-		"JENKINS_USERNAME":        "jenkins.username",
-		"JENKINS_PASSWORD":        "jenkins.password",
-		"JENKINS_API_TOKEN":       "jenkins.apiToken",
-		"JENKINS_TIMEOUT":         "jenkins.timeout",
-		"JENKINS_TLS_SKIP_VERIFY": "jenkins.tls.skipVerify",
-		"JENKINS_CA_CERT":         "jenkins.tls.caCert",
-		"JENKINS_MAX_RETRIES":     "jenkins.retry.maxAttempts",
-		"JENKINS_RETRY_BACKOFF":   "jenkins.retry.backoff",
+		"JENKINS_URL":                  "jenkins.url",
+		"JENKINS_USERNAME":             "jenkins.username",
+		"JENKINS_PASSWORD":             "jenkins.password",
+		"JENKINS_API_TOKEN":            "jenkins.apiToken",
+		"JENKINS_TIMEOUT":              "jenkins.timeout",
+		"JENKINS_TLS_SKIP_VERIFY":      "jenkins.tls.skipVerify",
+		"JENKINS_CA_CERT":              "jenkins.tls.caCert",
+		"JENKINS_MAX_RETRIES":          "jenkins.retry.maxAttempts",
+		"JENKINS_RETRY_BACKOFF":        "jenkins.retry.backoff",
+		"JENKINS_NAME":                 "jenkins.name",
+		"MCP_TRANSPORT":                "server.transport",
+		"MCP_LISTEN_ADDR":              "server.listenAddr",
+		"MCP_SHUTDOWN_TIMEOUT":         "server.shutdownTimeout",
+		"MCP_READ_ONLY":                "policy.readOnly",
+		"MCP_ALLOW_TOOLS":              "policy.allowTools",
+		"MCP_DENY_TOOLS":               "policy.denyTools",
+		"MCP_CONFIRM_MODE":             "confirmation.mode",
+		"MCP_CONFIRM_TOOLS":            "confirmation.tools",
+		"MCP_CONFIRM_TOKEN_TTL":        "confirmation.tokenTTL",
+		"JENKINS_CACHE_ENABLED":        "cache.enabled",
+		"JENKINS_CACHE_TTLS":           "cache.ttls",
+		"JENKINS_CACHE_MAX_ENTRIES":    "cache.maxEntries",
+		"JENKINS_CACHE_MAX_ENTRY_SIZE": "cache.maxEntrySize",
+		"MCP_METRICS_ENABLED":          "metrics.enabled",
+		"MCP_METRICS_LISTEN_ADDR":      "metrics.listenAddr",
+		"MCP_METRICS_PATH":             "metrics.path",
+		"MCP_TRACING_ENABLED":          "tracing.enabled",
+		"MCP_TRACING_EXPORTER":         "tracing.exporter",
+		"MCP_TRACING_ENDPOINT":         "tracing.endpoint",
+		"MCP_TRACING_PROTOCOL":         "tracing.protocol",
+		"MCP_TRACING_INSECURE":         "tracing.insecure",
+		"MCP_TRACING_SAMPLE_RATIO":     "tracing.sampleRatio",
+		"MCP_AUDIT_FILE":               "audit.file",
+		"MCP_AUDIT_WEBHOOK_URL":        "audit.webhookURL",
+		"MCP_AUDIT_WEBHOOK_TOKEN":      "audit.webhookToken",
+		"MCP_AUDIT_WEBHOOK_TIMEOUT":    "audit.webhookTimeout",
+		"MCP_REDACTION_ENABLED":        "redaction.enabled",
+		"MCP_REDACTION_PATTERNS":       "redaction.patterns",
 	}
 
 	for envVar, configKey := range envBindings {
@@ -193,3 +806,84 @@ func bindEnvVariables(v *viper.Viper) {
 		}
 	}
 }
+
+// getPatternList reads a list of regular expressions. Unlike getStringList it
+// does not split on commas, which are common in patterns; a plain string (as
+// environment variables are) is taken as a single pattern.
+func getPatternList(v *viper.Viper, key string) []string {
+	if pattern, ok := v.Get(key).(string); ok {
+		if pattern == "" {
+			return nil
+		}
+		return []string{pattern}
+	}
+	return v.GetStringSlice(key)
+}
+
+// getStringList reads a list setting that may be given as a YAML list or as a
+// comma-separated string (as environment variables are)
+func getStringList(v *viper.Viper, key string) []string {
+	var items []string
+	for _, item := range v.GetStringSlice(key) {
+		for _, part := range strings.Split(item, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				items = append(items, part)
+			}
+		}
+	}
+	return items
+}
+
+// getDurationMap reads a map of durations that may be given as a YAML mapping
+// or as a comma-separated list of key=duration pairs, e.g. "job=30s,build=5m".
+// Keys are lower-cased, as viper does for YAML mappings.
+func getDurationMap(v *viper.Viper, key string) (map[string]time.Duration, error) {
+	raw := map[string]string{}
+	if s, ok := v.Get(key).(string); ok {
+		for _, pair := range strings.Split(s, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			k, val, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("bad entry %q: want key=duration", pair)
+			}
+			raw[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(val)
+		}
+	} else {
+		raw = v.GetStringMapString(key)
+	}
+
+	durations := make(map[string]time.Duration, len(raw))
+	for k, val := range raw {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("bad duration for %s: %w", k, err)
+		}
+		durations[k] = d
+	}
+	return durations, nil
+}
+
+// applyInstanceEnv fills in missing instance credentials from environment
+// variables named after the instance, e.g. JENKINS_STAGING_API_TOKEN
+func applyInstanceEnv(inst *InstanceConfig) {
+	prefix := "JENKINS_" + strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(inst.Name)) + "_"
+
+	fields := map[string]*string{
+		"URL":       &inst.JenkinsURL,
+		"USERNAME":  &inst.Username,
+		"PASSWORD":  &inst.Password,
+		"API_TOKEN": &inst.APIToken,
+	}
+	for suffix, field := range fields {
+		if *field == "" {
+			*field = os.Getenv(prefix + suffix)
+		}
+	}
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/spf13/viper"
 )
 
 func TestValidateURL(t *testing.T) {
@@ -114,6 +117,603 @@ func TestValidateTimeout(t *testing.T) {
 	}
 }
 
+func TestValidateTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		transport  string
+		listenAddr string
+		wantErr    bool
+	}{
+		{
+			name:      "empty transport defaults to stdio",
+			transport: "",
+			wantErr:   false,
+		},
+		{
+			name:      "stdio transport",
+			transport: TransportStdio,
+			wantErr:   false,
+		},
+		{
+			name:       "sse transport with listen address",
+			transport:  TransportSSE,
+			listenAddr: ":8080",
+			wantErr:    false,
+		},
+		{
+			name:       "streamable http transport with listen address",
+			transport:  TransportStreamableHTTP,
+			listenAddr: "0.0.0.0:9000",
+			wantErr:    false,
+		},
+		{
+			name:      "http transport without listen address",
+			transport: TransportStreamableHTTP,
+			wantErr:   true,
+		},
+		{
+			name:       "unsupported transport",
+			transport:  "websocket",
+			listenAddr: ":8080",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Transport: tt.transport, ListenAddr: tt.listenAddr}
+			err := cfg.ValidateTransport()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			cfg:     Config{},
+			wantErr: false,
+		},
+		{
+			name:    "enabled with defaults",
+			cfg:     Config{Metrics: MetricsConfig{Enabled: true, ListenAddr: ":9090", Path: "/metrics"}},
+			wantErr: false,
+		},
+		{
+			name:    "enabled without listen address",
+			cfg:     Config{Metrics: MetricsConfig{Enabled: true, Path: "/metrics"}},
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			cfg:     Config{Metrics: MetricsConfig{Enabled: true, ListenAddr: ":9090", Path: "metrics"}},
+			wantErr: true,
+		},
+		{
+			name: "same address as http transport",
+			cfg: Config{
+				Transport:  TransportStreamableHTTP,
+				ListenAddr: ":8080",
+				Metrics:    MetricsConfig{Enabled: true, ListenAddr: ":8080", Path: "/metrics"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "same address as unused listener of stdio transport",
+			cfg: Config{
+				Transport:  TransportStdio,
+				ListenAddr: ":8080",
+				Metrics:    MetricsConfig{Enabled: true, ListenAddr: ":8080", Path: "/metrics"},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateMetrics()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTracing(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracing TracingConfig
+		wantErr bool
+	}{
+		{
+			name:    "disabled with unknown exporter",
+			tracing: TracingConfig{Exporter: "zipkin"},
+			wantErr: false,
+		},
+		{
+			name:    "otlp over grpc",
+			tracing: TracingConfig{Enabled: true, Exporter: TracingExporterOTLP, Protocol: TracingProtocolGRPC, SampleRatio: 1},
+			wantErr: false,
+		},
+		{
+			name:    "stdout",
+			tracing: TracingConfig{Enabled: true, Exporter: TracingExporterStdout, SampleRatio: 0.5},
+			wantErr: false,
+		},
+		{
+			name:    "unsupported exporter",
+			tracing: TracingConfig{Enabled: true, Exporter: "zipkin"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported protocol",
+			tracing: TracingConfig{Enabled: true, Protocol: "thrift"},
+			wantErr: true,
+		},
+		{
+			name:    "sample ratio above one",
+			tracing: TracingConfig{Enabled: true, SampleRatio: 1.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tracing.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAudit(t *testing.T) {
+	tests := []struct {
+		name    string
+		audit   AuditConfig
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			audit:   AuditConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "file only",
+			audit:   AuditConfig{File: "/var/log/jenkins-mcp/audit.jsonl"},
+			wantErr: false,
+		},
+		{
+			name:    "webhook",
+			audit:   AuditConfig{WebhookURL: "https://audit.example.com/events", WebhookTimeout: 5 * time.Second},
+			wantErr: false,
+		},
+		{
+			name:    "webhook with unsupported scheme",
+			audit:   AuditConfig{WebhookURL: "ftp://audit.example.com", WebhookTimeout: 5 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "webhook without host",
+			audit:   AuditConfig{WebhookURL: "https://", WebhookTimeout: 5 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "webhook without timeout",
+			audit:   AuditConfig{WebhookURL: "https://audit.example.com/events"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.audit.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PolicyConfig
+		wantErr bool
+	}{
+		{
+			name:    "empty policy",
+			policy:  PolicyConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid tool and job patterns",
+			policy: PolicyConfig{
+				ReadOnly:     true,
+				AllowedTools: []string{"jenkins_get_*", "jenkins_list_*"},
+				DeniedTools:  []string{"jenkins_delete_job"},
+				JobRestrictions: map[string][]string{
+					"jenkins_trigger_build": {"sandbox-*", "team-a/**"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "malformed allowed tool pattern",
+			policy:  PolicyConfig{AllowedTools: []string{"jenkins_[get"}},
+			wantErr: true,
+		},
+		{
+			name: "malformed job pattern",
+			policy: PolicyConfig{
+				JobRestrictions: map[string][]string{"*": {"team-[a/**"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfirmation(t *testing.T) {
+	tests := []struct {
+		name         string
+		confirmation ConfirmationConfig
+		wantErr      bool
+	}{
+		{
+			name:         "empty mode",
+			confirmation: ConfirmationConfig{},
+			wantErr:      false,
+		},
+		{
+			name: "token mode with default tools",
+			confirmation: ConfirmationConfig{
+				Mode:     ConfirmModeToken,
+				Tools:    DefaultConfirmTools,
+				TokenTTL: 5 * time.Minute,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "off mode",
+			confirmation: ConfirmationConfig{Mode: ConfirmModeOff},
+			wantErr:      false,
+		},
+		{
+			name:         "unsupported mode",
+			confirmation: ConfirmationConfig{Mode: "prompt"},
+			wantErr:      true,
+		},
+		{
+			name:         "malformed tool pattern",
+			confirmation: ConfirmationConfig{Mode: ConfirmModeAuto, Tools: []string{"jenkins_[stop"}},
+			wantErr:      true,
+		},
+		{
+			name:         "negative token TTL",
+			confirmation: ConfirmationConfig{Mode: ConfirmModeToken, TokenTTL: -time.Second},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.confirmation.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		cache   CacheConfig
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			cache:   CacheConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "default TTLs",
+			cache:   CacheConfig{Enabled: true, TTLs: DefaultCacheTTLs, MaxEntries: DefaultCacheMaxEntries},
+			wantErr: false,
+		},
+		{
+			name:    "unknown endpoint",
+			cache:   CacheConfig{Enabled: true, TTLs: map[string]time.Duration{"jobs": time.Minute}},
+			wantErr: true,
+		},
+		{
+			name:    "negative TTL",
+			cache:   CacheConfig{Enabled: true, TTLs: map[string]time.Duration{CacheEndpointBuild: -time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "negative max entries",
+			cache:   CacheConfig{Enabled: true, MaxEntries: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cache.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateInstances(t *testing.T) {
+	base := Config{
+		JenkinsURL:   "https://prod.example.com",
+		Username:     "user",
+		APIToken:     "token",
+		Timeout:      30 * time.Second,
+		InstanceName: "prod",
+	}
+
+	tests := []struct {
+		name      string
+		instances []InstanceConfig
+		wantErr   bool
+	}{
+		{
+			name:      "no additional instances",
+			instances: nil,
+			wantErr:   false,
+		},
+		{
+			name: "valid named instance",
+			instances: []InstanceConfig{
+				{Name: "staging", JenkinsURL: "https://staging.example.com", Username: "user", APIToken: "token"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing name",
+			instances: []InstanceConfig{
+				{JenkinsURL: "https://staging.example.com", Username: "user", APIToken: "token"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "name clashes with default instance",
+			instances: []InstanceConfig{
+				{Name: "prod", JenkinsURL: "https://other.example.com", Username: "user", APIToken: "token"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			instances: []InstanceConfig{
+				{Name: "staging", JenkinsURL: "https://staging.example.com", Username: "user", APIToken: "token"},
+				{Name: "staging", JenkinsURL: "https://staging2.example.com", Username: "user", APIToken: "token"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance without credentials",
+			instances: []InstanceConfig{
+				{Name: "legacy", JenkinsURL: "https://legacy.example.com"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.Instances = tt.instances
+			err := cfg.ValidateInstances()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInstances() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForInstance(t *testing.T) {
+	cfg := &Config{
+		JenkinsURL:    "https://prod.example.com",
+		Username:      "prod-user",
+		APIToken:      "prod-token",
+		Timeout:       30 * time.Second,
+		TLSSkipVerify: true,
+		MaxRetries:    3,
+		RetryBackoff:  time.Second,
+		Instances: []InstanceConfig{
+			{Name: "staging", JenkinsURL: "https://staging.example.com", Username: "staging-user", APIToken: "staging-token"},
+		},
+	}
+
+	got := cfg.ForInstance(InstanceConfig{
+		Name:       "staging",
+		JenkinsURL: "https://staging.example.com",
+		Username:   "staging-user",
+		APIToken:   "staging-token",
+		Retry:      InstanceRetry{MaxAttempts: 5},
+	})
+
+	if got.InstanceName != "staging" || got.JenkinsURL != "https://staging.example.com" {
+		t.Errorf("ForInstance() = %s %s, want staging https://staging.example.com", got.InstanceName, got.JenkinsURL)
+	}
+	if got.Username != "staging-user" || got.APIToken != "staging-token" {
+		t.Errorf("ForInstance() credentials = %s/%s, want the instance's own", got.Username, got.APIToken)
+	}
+	if got.TLSSkipVerify {
+		t.Error("ForInstance() inherited TLSSkipVerify from the default instance")
+	}
+	if got.Timeout != 30*time.Second || got.RetryBackoff != time.Second {
+		t.Errorf("ForInstance() did not inherit timeout/backoff: %v %v", got.Timeout, got.RetryBackoff)
+	}
+	if got.MaxRetries != 5 {
+		t.Errorf("ForInstance() MaxRetries = %d, want 5", got.MaxRetries)
+	}
+	if got.Instances != nil {
+		t.Error("ForInstance() should not carry the instance list")
+	}
+	if cfg.JenkinsURL != "https://prod.example.com" {
+		t.Error("ForInstance() modified the original configuration")
+	}
+}
+
+func TestGetStringList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{
+			name:  "yaml list",
+			value: []string{"jenkins_get_job", "jenkins_list_*"},
+			want:  []string{"jenkins_get_job", "jenkins_list_*"},
+		},
+		{
+			name:  "comma separated string",
+			value: "jenkins_get_job, jenkins_list_*,",
+			want:  []string{"jenkins_get_job", "jenkins_list_*"},
+		},
+		{
+			name:  "unset",
+			value: nil,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			if tt.value != nil {
+				v.Set("policy.allowTools", tt.value)
+			}
+			got := getStringList(v, "policy.allowTools")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStringList() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDurationMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name:  "yaml mapping",
+			value: map[string]any{"job": "1m", "testReport": "10s"},
+			want:  map[string]time.Duration{"job": time.Minute, "testreport": 10 * time.Second},
+		},
+		{
+			name:  "comma separated string",
+			value: "job=1m, Build=0s,",
+			want:  map[string]time.Duration{"job": time.Minute, "build": 0},
+		},
+		{
+			name:  "unset",
+			value: nil,
+			want:  map[string]time.Duration{},
+		},
+		{
+			name:    "missing duration",
+			value:   "job",
+			wantErr: true,
+		},
+		{
+			name:    "bad duration",
+			value:   "job=soon",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			if tt.value != nil {
+				v.Set("cache.ttls", tt.value)
+			}
+			got, err := getDurationMap(v, "cache.ttls")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDurationMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDurationMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPatternList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{
+			name:  "yaml list",
+			value: []any{`key-[0-9]{4,8}`, `pin=(\d+)`},
+			want:  []string{`key-[0-9]{4,8}`, `pin=(\d+)`},
+		},
+		{
+			name:  "single pattern with commas",
+			value: `key-[0-9]{4,8}`,
+			want:  []string{`key-[0-9]{4,8}`},
+		},
+		{
+			name:  "unset",
+			value: nil,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			if tt.value != nil {
+				v.Set("redaction.patterns", tt.value)
+			}
+			if got := getPatternList(v, "redaction.patterns"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPatternList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRedaction(t *testing.T) {
+	valid := RedactionConfig{Enabled: true, Patterns: []string{`pin=(\d+)`}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	invalid := RedactionConfig{Enabled: true, Patterns: []string{`pin=(\d+`}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate() succeeded, want an error for an invalid pattern")
+	}
+}
+
 func TestValidate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -242,4 +842,12 @@ func TestLoadFromEnvironment(t *testing.T) {
 	if cfg.MaxRetries != 3 {
 		t.Errorf("MaxRetries = %v, want %v", cfg.MaxRetries, 3)
 	}
+
+	if cfg.Transport != TransportStdio {
+		t.Errorf("Transport = %v, want %v", cfg.Transport, TransportStdio)
+	}
+
+	if cfg.Confirmation.Mode != ConfirmModeAuto {
+		t.Errorf("Confirmation.Mode = %v, want %v", cfg.Confirmation.Mode, ConfirmModeAuto)
+	}
 }
@@ -0,0 +1,272 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Build history paging settings for ListBuilds
+const (
+	defaultListBuildsLimit = 20
+	maxListBuildsLimit     = 100
+	listBuildsBatchSize    = 50
+	maxListBuildsScan      = 500 // Builds examined per call before a cursor is returned
+)
+
+// BuildResultRunning is the ListBuilds result filter matching builds in progress
+const BuildResultRunning = "RUNNING"
+
+// buildResults lists the result filters accepted by ListBuilds
+var buildResults = map[string]bool{
+	"SUCCESS":          true,
+	"UNSTABLE":         true,
+	"FAILURE":          true,
+	"NOT_BUILT":        true,
+	"ABORTED":          true,
+	BuildResultRunning: true,
+}
+
+// buildHistoryTree selects the build fields needed to list and filter build history
+const buildHistoryTree = "number,displayName,url,result,building,timestamp,duration," +
+	"actions[" + causeTree + ",parameters[name,value]]"
+
+// ListBuilds lists a job's builds, newest first, that match the given filters.
+// Builds are fetched in ranges of allBuilds and filtered client-side; a page ends
+// when Limit builds matched, the history is exhausted or enough builds were
+// examined, in which case NextCursor continues the listing.
+func (c *Client) ListBuilds(ctx context.Context, jobName string, opts ListBuildsOptions) (*BuildPage, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultListBuildsLimit
+	}
+	if limit > maxListBuildsLimit {
+		limit = maxListBuildsLimit
+	}
+
+	result := strings.ToUpper(opts.Result)
+	if result != "" && !buildResults[result] {
+		return nil, NewInvalidInputError(fmt.Sprintf("invalid result filter %q: must be one of SUCCESS, UNSTABLE, FAILURE, NOT_BUILT, ABORTED or RUNNING", opts.Result))
+	}
+
+	index, before, err := decodeBuildCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &BuildPage{Builds: []BuildSummary{}}
+	lastNumber := before
+	positioned := before == 0
+	for page.Scanned < maxListBuildsScan {
+		batch, err := c.getBuildRange(ctx, jobName, index, index+listBuildsBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		// The cursor's index is only a hint: builds deleted since the previous
+		// page shift the history towards the newest build, so step back until
+		// the batch starts no later than the last build examined
+		if !positioned {
+			if index > 0 && (len(batch) == 0 || batch[0].Number < before) {
+				index = max(0, index-listBuildsBatchSize)
+				continue
+			}
+			positioned = true
+		}
+
+		for _, build := range batch {
+			index++
+			// Builds started since the previous page shift the history; skip
+			// the ones that were already examined
+			if before > 0 && build.Number >= before {
+				continue
+			}
+			page.Scanned++
+			lastNumber = build.Number
+
+			// History is newest first, so no older build can match
+			if !opts.Since.IsZero() && build.Timestamp < opts.Since.UnixMilli() {
+				return page, nil
+			}
+
+			if matchesBuildFilter(build, result, opts) {
+				page.Builds = append(page.Builds, build)
+				if len(page.Builds) == limit {
+					page.NextCursor = encodeBuildCursor(index, lastNumber)
+					return page, nil
+				}
+			}
+		}
+
+		if len(batch) < listBuildsBatchSize {
+			return page, nil
+		}
+	}
+
+	page.NextCursor = encodeBuildCursor(index, lastNumber)
+	return page, nil
+}
+
+// getBuildRange fetches builds [from, to) of a job's history, newest first
+func (c *Client) getBuildRange(ctx context.Context, jobName string, from, to int) ([]BuildSummary, error) {
+	// The range braces are percent-encoded as they are not valid in a URL query
+	path := fmt.Sprintf("%s/api/json?tree=allBuilds[%s]%%7B%d,%d%%7D", jobPath(jobName), buildHistoryTree, from, to)
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	var rawResult struct {
+		AllBuilds []struct {
+			Number      int    `json:"number"`
+			DisplayName string `json:"displayName"`
+			URL         string `json:"url"`
+			Result      string `json:"result"`
+			Building    bool   `json:"building"`
+			Timestamp   int64  `json:"timestamp"`
+			Duration    int64  `json:"duration"`
+			Actions     []struct {
+				Causes     []rawCause `json:"causes"`
+				Parameters []struct {
+					Name  string      `json:"name"`
+					Value interface{} `json:"value"`
+				} `json:"parameters"`
+			} `json:"actions"`
+		} `json:"allBuilds"`
+	}
+	if err := json.Unmarshal(body, &rawResult); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	builds := make([]BuildSummary, 0, len(rawResult.AllBuilds))
+	for _, raw := range rawResult.AllBuilds {
+		build := BuildSummary{
+			Number:      raw.Number,
+			DisplayName: raw.DisplayName,
+			URL:         raw.URL,
+			Result:      raw.Result,
+			Building:    raw.Building,
+			Timestamp:   raw.Timestamp,
+			Duration:    raw.Duration,
+		}
+		for _, action := range raw.Actions {
+			build.Causes = append(build.Causes, buildCauses(action.Causes)...)
+			for _, param := range action.Parameters {
+				if build.Parameters == nil {
+					build.Parameters = make(map[string]string)
+				}
+				build.Parameters[param.Name] = parameterValueString(param.Value)
+			}
+		}
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
+
+// matchesBuildFilter reports whether a build passes the result, time, user and parameter filters
+func matchesBuildFilter(build BuildSummary, result string, opts ListBuildsOptions) bool {
+	switch {
+	case result == BuildResultRunning:
+		if !build.Building {
+			return false
+		}
+	case result != "":
+		if build.Building || build.Result != result {
+			return false
+		}
+	}
+
+	if !opts.Until.IsZero() && build.Timestamp >= opts.Until.UnixMilli() {
+		return false
+	}
+
+	if opts.User != "" {
+		found := false
+		for _, cause := range build.Causes {
+			if strings.EqualFold(cause.UserID, opts.User) || strings.EqualFold(cause.UserName, opts.User) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for name, value := range opts.Parameters {
+		actual, ok := build.Parameters[name]
+		if !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parameterValueString renders a build parameter value the way it is passed when triggering a build
+func parameterValueString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+// encodeBuildCursor records where the next page starts: the number of the last
+// build examined, which only older builds follow, and the allBuilds index after
+// it as a hint of where to find them
+func encodeBuildCursor(index, lastNumber int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", index, lastNumber)))
+}
+
+// decodeBuildCursor parses a cursor created by encodeBuildCursor; an empty cursor starts at the newest build
+func decodeBuildCursor(cursor string) (int, int, error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+
+	invalid := NewInvalidInputError("invalid cursor: pass the nextCursor value of a previous page unchanged")
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, invalid
+	}
+
+	var index, lastNumber int
+	if _, err := fmt.Sscanf(string(data), "%d:%d", &index, &lastNumber); err != nil || index < 0 || lastNumber < 0 {
+		return 0, 0, invalid
+	}
+
+	return index, lastNumber, nil
+}
@@ -0,0 +1,185 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildHistoryHandler serves allBuilds ranges of a job with builds numbered total..1.
+// Even builds failed, every fifth was started by alice with ENV=prod, and build
+// n started n hours after the epoch.
+func buildHistoryHandler(t *testing.T, total int, requests *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/deploy/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		*requests++
+
+		tree := r.URL.Query().Get("tree")
+		var from, to int
+		if _, err := fmt.Sscanf(tree[strings.LastIndex(tree, "{"):], "{%d,%d}", &from, &to); err != nil {
+			t.Errorf("tree %q has no range: %v", tree, err)
+		}
+
+		builds := []map[string]interface{}{}
+		for i := from; i < to && i < total; i++ {
+			number := total - i
+			build := map[string]interface{}{
+				"number":    number,
+				"url":       fmt.Sprintf("http://jenkins/job/deploy/%d/", number),
+				"result":    "SUCCESS",
+				"timestamp": int64(number) * time.Hour.Milliseconds(),
+				"actions":   []interface{}{},
+			}
+			if number%2 == 0 {
+				build["result"] = "FAILURE"
+			}
+			if number%5 == 0 {
+				build["actions"] = []interface{}{
+					map[string]interface{}{"causes": []interface{}{map[string]interface{}{"shortDescription": "Started by user Alice", "userId": "alice", "userName": "Alice"}}},
+					map[string]interface{}{"parameters": []interface{}{map[string]interface{}{"name": "ENV", "value": "prod"}, map[string]interface{}{"name": "DRY_RUN", "value": false}}},
+				}
+			}
+			builds = append(builds, build)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"allBuilds": builds})
+	})
+}
+
+func TestListBuilds(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, buildHistoryHandler(t, 120, &requests))
+	ctx := context.Background()
+
+	page, err := client.ListBuilds(ctx, "deploy", ListBuildsOptions{Result: "failure", Limit: 3})
+	if err != nil {
+		t.Fatalf("ListBuilds() error = %v", err)
+	}
+	if got := buildNumbers(page.Builds); got != "120,118,116" {
+		t.Errorf("first page = %s, want 120,118,116", got)
+	}
+	if page.NextCursor == "" {
+		t.Fatal("first page has no NextCursor")
+	}
+
+	page, err = client.ListBuilds(ctx, "deploy", ListBuildsOptions{Result: "FAILURE", Limit: 3, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("ListBuilds() with cursor error = %v", err)
+	}
+	if got := buildNumbers(page.Builds); got != "114,112,110" {
+		t.Errorf("second page = %s, want 114,112,110", got)
+	}
+
+	page, err = client.ListBuilds(ctx, "deploy", ListBuildsOptions{User: "ALICE", Parameters: map[string]string{"ENV": "prod", "DRY_RUN": "false"}, Limit: 100})
+	if err != nil {
+		t.Fatalf("ListBuilds() with user filter error = %v", err)
+	}
+	if len(page.Builds) != 24 || page.NextCursor != "" || page.Scanned != 120 {
+		t.Errorf("user filter returned %d builds, cursor %q, scanned %d; want 24, no cursor, 120", len(page.Builds), page.NextCursor, page.Scanned)
+	}
+
+	requests = 0
+	page, err = client.ListBuilds(ctx, "deploy", ListBuildsOptions{
+		Since: time.UnixMilli(100 * time.Hour.Milliseconds()),
+		Until: time.UnixMilli(110 * time.Hour.Milliseconds()),
+	})
+	if err != nil {
+		t.Fatalf("ListBuilds() with date range error = %v", err)
+	}
+	if got := buildNumbers(page.Builds); got != "109,108,107,106,105,104,103,102,101,100" {
+		t.Errorf("date range = %s, want 109..100", got)
+	}
+	if requests != 1 {
+		t.Errorf("date range made %d requests, want 1", requests)
+	}
+}
+
+func TestListBuildsCursorSkipsNewBuilds(t *testing.T) {
+	requests := 0
+	total := 10
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buildHistoryHandler(t, total, &requests).ServeHTTP(w, r)
+	}))
+
+	page, err := client.ListBuilds(context.Background(), "deploy", ListBuildsOptions{Limit: 4})
+	if err != nil {
+		t.Fatalf("ListBuilds() error = %v", err)
+	}
+
+	// Two builds start before the next page is requested
+	total = 12
+	page, err = client.ListBuilds(context.Background(), "deploy", ListBuildsOptions{Limit: 4, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("ListBuilds() with cursor error = %v", err)
+	}
+	if got := buildNumbers(page.Builds); got != "6,5,4,3" {
+		t.Errorf("second page = %s, want 6,5,4,3", got)
+	}
+}
+
+func TestListBuildsCursorAfterDeletedBuilds(t *testing.T) {
+	numbers := []int{20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tree := r.URL.Query().Get("tree")
+		var from, to int
+		fmt.Sscanf(tree[strings.LastIndex(tree, "{"):], "{%d,%d}", &from, &to)
+
+		builds := []map[string]interface{}{}
+		for i := from; i < to && i < len(numbers); i++ {
+			builds = append(builds, map[string]interface{}{"number": numbers[i], "result": "SUCCESS"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"allBuilds": builds})
+	}))
+
+	page, err := client.ListBuilds(context.Background(), "deploy", ListBuildsOptions{Limit: 4})
+	if err != nil {
+		t.Fatalf("ListBuilds() error = %v", err)
+	}
+
+	// Builds 18 and 15 are deleted before the next page is requested
+	numbers = []int{20, 19, 17, 16, 14, 13, 12, 11, 10}
+	page, err = client.ListBuilds(context.Background(), "deploy", ListBuildsOptions{Limit: 4, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("ListBuilds() with cursor error = %v", err)
+	}
+	if got := buildNumbers(page.Builds); got != "16,14,13,12" {
+		t.Errorf("second page = %s, want 16,14,13,12", got)
+	}
+}
+
+func TestListBuildsValidation(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+
+	tests := []struct {
+		name    string
+		jobName string
+		opts    ListBuildsOptions
+	}{
+		{name: "empty job name", jobName: "", opts: ListBuildsOptions{}},
+		{name: "unknown result", jobName: "deploy", opts: ListBuildsOptions{Result: "GREEN"}},
+		{name: "malformed cursor", jobName: "deploy", opts: ListBuildsOptions{Cursor: "not a cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ListBuilds(context.Background(), tt.jobName, tt.opts)
+			if !IsErrorCode(err, ErrorCodeInvalidInput) {
+				t.Errorf("ListBuilds() error = %v, want %s", err, ErrorCodeInvalidInput)
+			}
+		})
+	}
+}
+
+func buildNumbers(builds []BuildSummary) string {
+	numbers := make([]string, len(builds))
+	for i, build := range builds {
+		numbers[i] = fmt.Sprint(build.Number)
+	}
+	return strings.Join(numbers, ",")
+}
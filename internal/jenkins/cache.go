@@ -0,0 +1,355 @@
+package jenkins
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CacheStats reports how effective a client's response cache has been
+type CacheStats struct {
+	// Hits counts reads served from a fresh cache entry without contacting Jenkins
+	Hits int64 `json:"hits"`
+	// Revalidations counts reads of stale entries that Jenkins confirmed unchanged (304 Not Modified)
+	Revalidations int64 `json:"revalidations"`
+	// Misses counts cacheable reads that had to be fetched from Jenkins
+	Misses int64 `json:"misses"`
+	// Evictions counts entries dropped to stay within the entry limit
+	Evictions int64 `json:"evictions"`
+	// Invalidations counts entries dropped because a mutating call made them stale
+	Invalidations int64 `json:"invalidations"`
+	// Entries is the number of responses currently cached
+	Entries int `json:"entries"`
+}
+
+// responseCache caches successful GET responses in memory, keyed by request
+// path. Each endpoint class has its own TTL; stale entries that carry an
+// ETag or Last-Modified header are revalidated with a conditional request.
+type responseCache struct {
+	ttls         map[string]time.Duration
+	maxEntries   int
+	maxEntrySize int64
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // most recently used at the front
+	stats   CacheStats
+}
+
+// cacheEntry is a cached response body with its headers and validators
+type cacheEntry struct {
+	key          string
+	header       http.Header
+	body         []byte
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+// bypassCacheContextKey marks a context whose reads must reach Jenkins
+type bypassCacheContextKey struct{}
+
+// withoutCache returns a context whose GET requests skip the response cache,
+// for reads a mutation is decided on
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheContextKey{}, true)
+}
+
+// cacheBypassed reports whether withoutCache marked the context
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheContextKey{}).(bool)
+	return bypass
+}
+
+// newResponseCache creates a response cache, or returns nil when caching is disabled
+func newResponseCache(cfg config.CacheConfig) *responseCache {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	rc := &responseCache{
+		ttls:         cfg.TTLs,
+		maxEntries:   cfg.MaxEntries,
+		maxEntrySize: cfg.MaxEntrySize,
+		now:          time.Now,
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+	}
+	if rc.ttls == nil {
+		rc.ttls = config.DefaultCacheTTLs
+	}
+	if rc.maxEntries <= 0 {
+		rc.maxEntries = config.DefaultCacheMaxEntries
+	}
+	if rc.maxEntrySize <= 0 {
+		rc.maxEntrySize = config.DefaultCacheMaxEntrySize
+	}
+
+	return rc
+}
+
+// fetch serves a GET request from the cache when a fresh entry exists.
+// Otherwise it sends the request with send, revalidating a stale entry when
+// possible, and caches a successful response.
+func (rc *responseCache) fetch(path string, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	endpoint := cacheEndpoint(path)
+	if endpoint == "" {
+		return send(req)
+	}
+
+	rc.mu.Lock()
+	var stale *cacheEntry
+	if elem, ok := rc.entries[path]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if rc.now().Before(entry.expires) {
+			rc.lru.MoveToFront(elem)
+			rc.stats.Hits++
+			rc.mu.Unlock()
+			trace.SpanFromContext(req.Context()).SetAttributes(tracing.CacheHitKey.Bool(true))
+			return entry.response(req), nil
+		}
+		stale = entry
+	}
+	rc.mu.Unlock()
+
+	if stale != nil {
+		if stale.etag != "" {
+			req.Header.Set("If-None-Match", stale.etag)
+		}
+		if stale.lastModified != "" {
+			req.Header.Set("If-Modified-Since", stale.lastModified)
+		}
+	}
+
+	resp, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		rc.mu.Lock()
+		stale.expires = rc.now().Add(rc.ttls[endpoint])
+		if elem, ok := rc.entries[path]; ok && elem.Value == stale {
+			rc.lru.MoveToFront(elem)
+		}
+		rc.stats.Revalidations++
+		rc.mu.Unlock()
+		trace.SpanFromContext(req.Context()).SetAttributes(tracing.CacheHitKey.Bool(true))
+
+		return stale.response(req), nil
+	}
+
+	rc.mu.Lock()
+	rc.stats.Misses++
+	rc.mu.Unlock()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	ttl := rc.ttls[endpoint]
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if ttl <= 0 && etag == "" && lastModified == "" {
+		return resp, nil
+	}
+
+	// Read at most one byte more than the limit so oversized responses are
+	// passed through without buffering them entirely
+	body, err := io.ReadAll(io.LimitReader(resp.Body, rc.maxEntrySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+	if int64(len(body)) > rc.maxEntrySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A running build changes with every poll; only finished builds are cached,
+	// whether they are read directly or through the job's build history
+	if (endpoint == config.CacheEndpointBuild || endpoint == config.CacheEndpointJob) && bytes.Contains(body, []byte(`"building":true`)) {
+		return resp, nil
+	}
+
+	rc.put(&cacheEntry{
+		key:          path,
+		header:       resp.Header.Clone(),
+		body:         body,
+		etag:         etag,
+		lastModified: lastModified,
+		expires:      rc.now().Add(ttl),
+	})
+
+	return resp, nil
+}
+
+// put adds or replaces an entry, evicting the least recently used entries
+// beyond the entry limit
+func (rc *responseCache) put(entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if elem, ok := rc.entries[entry.key]; ok {
+		elem.Value = entry
+		rc.lru.MoveToFront(elem)
+		return
+	}
+
+	rc.entries[entry.key] = rc.lru.PushFront(entry)
+	for rc.lru.Len() > rc.maxEntries {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cacheEntry).key)
+		rc.stats.Evictions++
+	}
+}
+
+// invalidate drops the entries a mutating request to path may have made
+// stale: everything cached for the affected job, its folders and its
+// descendants, as well as the queue, node, view and root listings. Entries
+// of unrelated jobs are kept.
+func (rc *responseCache) invalidate(path string) {
+	mutated := jobPathPrefix(path)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, elem := range rc.entries {
+		cached := jobPathPrefix(key)
+		if cached != "" && (mutated == "" || (!isPathPrefix(cached, mutated) && !isPathPrefix(mutated, cached))) {
+			continue
+		}
+		rc.lru.Remove(elem)
+		delete(rc.entries, key)
+		rc.stats.Invalidations++
+	}
+}
+
+// Stats returns a snapshot of the cache counters
+func (rc *responseCache) Stats() CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	stats := rc.stats
+	stats.Entries = rc.lru.Len()
+	return stats
+}
+
+// response builds a fresh response for req from the cached entry
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheEndpoint classifies a request path into the endpoint class that
+// determines its TTL. It returns "" for paths that are never cached, such as
+// the progressive log endpoint, whose offset moves on every call.
+func cacheEndpoint(path string) string {
+	p, query, _ := strings.Cut(path, "?")
+
+	switch {
+	case p == "/api/json":
+		if strings.Contains(query, "tree=views") {
+			return config.CacheEndpointView
+		}
+		return config.CacheEndpointJob
+	case strings.HasPrefix(p, "/queue/"):
+		return config.CacheEndpointQueue
+	case strings.HasPrefix(p, "/computer/"):
+		return config.CacheEndpointNode
+	case strings.HasPrefix(p, "/view/"):
+		return config.CacheEndpointView
+	}
+
+	prefix := jobPathPrefix(p)
+	if prefix == "" {
+		return ""
+	}
+	rest := strings.TrimPrefix(p, prefix)
+
+	if rest == "/config.xml" {
+		return config.CacheEndpointConfig
+	}
+
+	// Paths below a build number belong to a single build
+	number, buildPath, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+	if _, err := strconv.Atoi(number); err != nil {
+		// The next build number moves whenever a build starts, including
+		// builds started outside this server
+		if rest == "/api/json" && !strings.Contains(query, "nextBuildNumber") {
+			return config.CacheEndpointJob
+		}
+		return ""
+	}
+
+	switch {
+	case buildPath == "api/json":
+		// A running build is only recognised, and left uncached, when the
+		// response reports whether the build is running
+		if strings.Contains(query, "tree=") && !strings.Contains(query, "building") {
+			return ""
+		}
+		return config.CacheEndpointBuild
+	case buildPath == "consoleText":
+		return config.CacheEndpointLog
+	case strings.HasPrefix(buildPath, "wfapi/"):
+		return config.CacheEndpointPipeline
+	case strings.HasPrefix(buildPath, "testReport/"):
+		return config.CacheEndpointTestReport
+	case strings.HasPrefix(buildPath, "artifact/"):
+		return config.CacheEndpointArtifact
+	}
+
+	return ""
+}
+
+// jobPathPrefix returns the leading "/job/<name>" segments of a request path,
+// e.g. "/job/team/job/app" for "/job/team/job/app/12/api/json", or "" when
+// the path does not address a job
+func jobPathPrefix(path string) string {
+	p, _, _ := strings.Cut(path, "?")
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+
+	n := 0
+	for n+1 < len(segments) && segments[n] == "job" {
+		n += 2
+	}
+	if n == 0 {
+		return ""
+	}
+	return "/" + strings.Join(segments[:n], "/")
+}
+
+// isPathPrefix reports whether prefix equals path or is one of its parent paths
+func isPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
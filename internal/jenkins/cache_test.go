@@ -0,0 +1,401 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+// newCachedTestClient creates a test client with the response cache enabled
+func newCachedTestClient(t *testing.T, handler http.Handler, cfg config.CacheConfig) *Client {
+	t.Helper()
+
+	client := newTestClient(t, handler)
+	cfg.Enabled = true
+	client.cache = newResponseCache(cfg)
+	return client
+}
+
+// requestCounter counts the requests a test server receives per method and path
+type requestCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (rc *requestCounter) add(r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.counts == nil {
+		rc.counts = map[string]int{}
+	}
+	rc.counts[r.Method+" "+r.URL.Path]++
+}
+
+func (rc *requestCounter) get(key string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.counts[key]
+}
+
+// cacheTestHandler serves job and build details and accepts build stops
+func cacheTestHandler(counter *requestCounter, building bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter.add(r)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/crumbIssuer/api/json":
+			http.NotFound(w, r)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/stop"):
+			w.WriteHeader(http.StatusOK)
+		case strings.HasSuffix(r.URL.Path, "/1/api/json"):
+			if building {
+				w.Write([]byte(`{"number":1,"building":true}`))
+			} else {
+				w.Write([]byte(`{"number":1,"building":false,"result":"SUCCESS"}`))
+			}
+		case strings.HasSuffix(r.URL.Path, "/api/json") && strings.HasPrefix(r.URL.Query().Get("tree"), "lastBuild["):
+			fmt.Fprintf(w, `{"lastBuild":{"number":1,"building":%t}}`, building)
+		case strings.HasSuffix(r.URL.Path, "/api/json"):
+			w.Write([]byte(`{"name":"app","fullName":"app","buildable":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestResponseCacheHitsAndMisses(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		job, err := client.GetJob(ctx, "team/app")
+		if err != nil {
+			t.Fatalf("GetJob() failed: %v", err)
+		}
+		if job.Name != "app" {
+			t.Errorf("GetJob() name = %q, want app", job.Name)
+		}
+	}
+
+	if got := counter.get("GET /job/team/job/app/api/json"); got != 1 {
+		t.Errorf("Jenkins received %d job requests, want 1", got)
+	}
+
+	stats := client.CacheStats()
+	if stats == nil {
+		t.Fatal("CacheStats() = nil, want counters")
+	}
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("CacheStats() = %+v, want 2 hits, 1 miss, 1 entry", *stats)
+	}
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{
+		TTLs: map[string]time.Duration{config.CacheEndpointJob: time.Minute},
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	client.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	client.GetJob(ctx, "app")
+	now = now.Add(30 * time.Second)
+	client.GetJob(ctx, "app")
+	if got := counter.get("GET /job/app/api/json"); got != 1 {
+		t.Errorf("Jenkins received %d requests within the TTL, want 1", got)
+	}
+
+	now = now.Add(time.Minute)
+	client.GetJob(ctx, "app")
+	if got := counter.get("GET /job/app/api/json"); got != 2 {
+		t.Errorf("Jenkins received %d requests after the TTL expired, want 2", got)
+	}
+
+	// Builds have no TTL configured and are not cached
+	client.GetBuild(ctx, "app", 1)
+	client.GetBuild(ctx, "app", 1)
+	if got := counter.get("GET /job/app/1/api/json"); got != 2 {
+		t.Errorf("Jenkins received %d build requests, want 2", got)
+	}
+}
+
+func TestResponseCacheSkipsRunningBuilds(t *testing.T) {
+	for _, building := range []bool{true, false} {
+		counter := &requestCounter{}
+		client := newCachedTestClient(t, cacheTestHandler(counter, building), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetBuild(context.Background(), "app", 1); err != nil {
+				t.Fatalf("GetBuild() failed: %v", err)
+			}
+			if _, err := client.GetLatestBuild(context.Background(), "app"); err != nil {
+				t.Fatalf("GetLatestBuild() failed: %v", err)
+			}
+		}
+
+		want := 1
+		if building {
+			want = 2
+		}
+		if got := counter.get("GET /job/app/1/api/json"); got != want {
+			t.Errorf("building=%v: Jenkins received %d build requests, want %d", building, got, want)
+		}
+		if got := counter.get("GET /job/app/api/json"); got != want {
+			t.Errorf("building=%v: Jenkins received %d latest build requests, want %d", building, got, want)
+		}
+	}
+}
+
+func TestResponseCacheSkipsArtifactsOfRunningBuilds(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter.add(r)
+		// The running build archives another artifact between the reads
+		n := counter.get("GET " + r.URL.Path)
+		var artifacts []string
+		for i := 1; i <= n; i++ {
+			artifacts = append(artifacts, fmt.Sprintf(`{"fileName":"part%d.log","relativePath":"part%d.log"}`, i, i))
+		}
+		building := ""
+		if strings.Contains(r.URL.Query().Get("tree"), "building") {
+			building = `"building":true,`
+		}
+		fmt.Fprintf(w, `{%s"artifacts":[%s]}`, building, strings.Join(artifacts, ","))
+	}), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+
+	for want := 1; want <= 2; want++ {
+		artifacts, err := client.ListArtifacts(context.Background(), "app", 1)
+		if err != nil {
+			t.Fatalf("ListArtifacts() failed: %v", err)
+		}
+		if len(artifacts) != want {
+			t.Errorf("read %d returned %d artifacts, want %d", want, len(artifacts), want)
+		}
+	}
+}
+
+func TestResponseCacheBypassedByRebuild(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter.add(r)
+		switch r.URL.Path {
+		case "/job/deploy/41/api/json":
+			w.Write([]byte(deployBuild))
+		case "/job/deploy/api/json":
+			w.Write([]byte(deployJob))
+		case "/job/deploy/buildWithParameters":
+			w.Header().Set("Location", "http://jenkins/queue/item/88/")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+	ctx := context.Background()
+
+	if _, err := client.GetBuildInputs(ctx, "deploy", 41); err != nil {
+		t.Fatalf("GetBuildInputs() failed: %v", err)
+	}
+	if _, err := client.GetJob(ctx, "deploy"); err != nil {
+		t.Fatalf("GetJob() failed: %v", err)
+	}
+	if _, err := client.RebuildBuild(ctx, "deploy", 41, nil); err != nil {
+		t.Fatalf("RebuildBuild() failed: %v", err)
+	}
+
+	for _, key := range []string{"GET /job/deploy/41/api/json", "GET /job/deploy/api/json"} {
+		if got := counter.get(key); got != 2 {
+			t.Errorf("Jenkins received %d requests for %s, want 2", got, key)
+		}
+	}
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+	ctx := context.Background()
+
+	client.GetJob(ctx, "team/app")
+	client.GetJob(ctx, "team/other")
+	client.ListJobs(ctx, "team")
+	client.GetBuild(ctx, "team/app", 1)
+
+	// Stopping a build of team/app makes the build, the job and its folder stale
+	client.cache.invalidate("/job/team/job/app/1/stop")
+
+	client.GetJob(ctx, "team/app")
+	client.GetJob(ctx, "team/other")
+	client.ListJobs(ctx, "team")
+	client.GetBuild(ctx, "team/app", 1)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "GET /job/team/job/app/api/json", want: 2},
+		{path: "GET /job/team/job/app/1/api/json", want: 2},
+		{path: "GET /job/team/api/json", want: 2},
+		{path: "GET /job/team/job/other/api/json", want: 1},
+	}
+	for _, tt := range tests {
+		if got := counter.get(tt.path); got != tt.want {
+			t.Errorf("%s: Jenkins received %d requests, want %d", tt.path, got, tt.want)
+		}
+	}
+
+	if stats := client.CacheStats(); stats.Invalidations != 3 {
+		t.Errorf("CacheStats().Invalidations = %d, want 3", stats.Invalidations)
+	}
+}
+
+func TestResponseCacheInvalidatedByMutatingCalls(t *testing.T) {
+	counter := &requestCounter{}
+	client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+	ctx := context.Background()
+
+	if _, err := client.GetBuild(ctx, "app", 1); err != nil {
+		t.Fatalf("GetBuild() failed: %v", err)
+	}
+	if _, err := client.GetBuild(ctx, "app", 1); err != nil {
+		t.Fatalf("GetBuild() failed: %v", err)
+	}
+	if got := counter.get("GET /job/app/1/api/json"); got != 1 {
+		t.Fatalf("Jenkins received %d build requests before the POST, want 1", got)
+	}
+
+	resp, err := client.doRequest(ctx, http.MethodPost, "/job/app/1/stop", nil)
+	if err != nil {
+		t.Fatalf("doRequest() failed: %v", err)
+	}
+	resp.Body.Close()
+
+	client.GetBuild(ctx, "app", 1)
+	if got := counter.get("GET /job/app/1/api/json"); got != 2 {
+		t.Errorf("Jenkins received %d build requests after a POST, want 2", got)
+	}
+}
+
+func TestResponseCacheRevalidation(t *testing.T) {
+	var mu sync.Mutex
+	var conditional int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			mu.Lock()
+			conditional++
+			mu.Unlock()
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("artifact contents"))
+	})
+	client := newCachedTestClient(t, handler, config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+
+	for i := 0; i < 3; i++ {
+		data, err := client.GetArtifact(context.Background(), "app", 1, "out/report.txt")
+		if err != nil {
+			t.Fatalf("GetArtifact() failed: %v", err)
+		}
+		if string(data) != "artifact contents" {
+			t.Errorf("GetArtifact() = %q, want cached contents", data)
+		}
+	}
+
+	if conditional != 2 {
+		t.Errorf("Jenkins received %d conditional requests, want 2", conditional)
+	}
+	if stats := client.CacheStats(); stats.Revalidations != 2 || stats.Misses != 1 || stats.Hits != 0 {
+		t.Errorf("CacheStats() = %+v, want 2 revalidations and 1 miss", *stats)
+	}
+}
+
+func TestResponseCacheLimits(t *testing.T) {
+	t.Run("evicts least recently used", func(t *testing.T) {
+		counter := &requestCounter{}
+		client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{
+			TTLs:       config.DefaultCacheTTLs,
+			MaxEntries: 2,
+		})
+		ctx := context.Background()
+
+		client.GetJob(ctx, "a")
+		client.GetJob(ctx, "b")
+		client.GetJob(ctx, "a")
+		client.GetJob(ctx, "c") // evicts b
+		client.GetJob(ctx, "a")
+		client.GetJob(ctx, "b")
+
+		if got := counter.get("GET /job/a/api/json"); got != 1 {
+			t.Errorf("Jenkins received %d requests for a, want 1", got)
+		}
+		if got := counter.get("GET /job/b/api/json"); got != 2 {
+			t.Errorf("Jenkins received %d requests for b, want 2", got)
+		}
+		if stats := client.CacheStats(); stats.Evictions != 2 || stats.Entries != 2 {
+			t.Errorf("CacheStats() = %+v, want 2 evictions and 2 entries", *stats)
+		}
+	})
+
+	t.Run("skips oversized responses", func(t *testing.T) {
+		counter := &requestCounter{}
+		client := newCachedTestClient(t, cacheTestHandler(counter, false), config.CacheConfig{
+			TTLs:         config.DefaultCacheTTLs,
+			MaxEntrySize: 10,
+		})
+
+		for i := 0; i < 2; i++ {
+			job, err := client.GetJob(context.Background(), "app")
+			if err != nil {
+				t.Fatalf("GetJob() failed: %v", err)
+			}
+			if job.Name != "app" {
+				t.Errorf("GetJob() name = %q, want the full response", job.Name)
+			}
+		}
+
+		if got := counter.get("GET /job/app/api/json"); got != 2 {
+			t.Errorf("Jenkins received %d requests, want 2", got)
+		}
+	})
+}
+
+func TestCacheEndpoint(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/json", want: config.CacheEndpointJob},
+		{path: "/api/json?tree=views[name,url,description]", want: config.CacheEndpointView},
+		{path: "/job/team/job/app/api/json", want: config.CacheEndpointJob},
+		{path: "/job/app/api/json?tree=allBuilds[number]%7B0,50%7D", want: config.CacheEndpointJob},
+		{path: "/job/app/api/json?tree=nextBuildNumber", want: ""},
+		{path: "/job/app/config.xml", want: config.CacheEndpointConfig},
+		{path: "/job/app/12/api/json", want: config.CacheEndpointBuild},
+		{path: "/job/app/12/api/json?tree=number,building,result", want: config.CacheEndpointBuild},
+		{path: "/job/app/12/api/json?tree=artifacts[fileName]", want: ""},
+		{path: "/job/app/12/consoleText", want: config.CacheEndpointLog},
+		{path: "/job/app/12/wfapi/describe", want: config.CacheEndpointPipeline},
+		{path: "/job/app/12/testReport/api/json", want: config.CacheEndpointTestReport},
+		{path: "/job/app/12/artifact/out/app.jar", want: config.CacheEndpointArtifact},
+		{path: "/job/app/12/logText/progressiveText?start=0", want: ""},
+		{path: "/job/app/lastBuild/api/json", want: ""},
+		{path: "/queue/item/5/api/json", want: config.CacheEndpointQueue},
+		{path: "/computer/api/json?tree=computer[displayName]", want: config.CacheEndpointNode},
+		{path: "/view/All/api/json", want: config.CacheEndpointView},
+		{path: "/crumbIssuer/api/json", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := cacheEndpoint(tt.path); got != tt.want {
+			t.Errorf("cacheEndpoint(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// maxCompareBuilds caps the number of builds CompareChanges fetches
+const maxCompareBuilds = 100
+
+// changeItemTree selects the commit fields of a changeset
+const changeItemTree = "items[commitId,author[fullName],authorEmail,msg,timestamp,affectedPaths]"
+
+// causeTree selects the fields of a build's causes
+const causeTree = "causes[_class,shortDescription,userId,userName,upstreamProject,upstreamBuild,upstreamUrl]"
+
+// buildTree selects the build fields returned by GetBuild and GetLatestBuild.
+// Freestyle builds report their changes as changeSet, pipeline builds as
+// changeSets; the Git plugin's BuildData actions carry the revisions.
+const buildTree = "number,url,result,building,duration,timestamp,executor[number],estimatedDuration," +
+	"actions[" + causeTree + ",lastBuiltRevision[SHA1,branch[name]],remoteUrls]," +
+	"changeSet[kind," + changeItemTree + "],changeSets[kind," + changeItemTree + "],culprits[fullName]"
+
+// causeKinds maps Jenkins cause classes to the kind of cause
+var causeKinds = map[string]string{
+	"hudson.model.Cause$UserIdCause":                                              CauseKindUser,
+	"hudson.model.Cause$UserCause":                                                CauseKindUser,
+	"hudson.triggers.SCMTrigger$SCMTriggerCause":                                  CauseKindSCM,
+	"com.cloudbees.jenkins.GitHubPushCause":                                       CauseKindSCM,
+	"com.dabsquared.gitlabjenkins.cause.GitLabWebHookCause":                       CauseKindSCM,
+	"jenkins.branch.BranchIndexingCause":                                          CauseKindSCM,
+	"jenkins.branch.BranchEventCause":                                             CauseKindSCM,
+	"hudson.model.Cause$UpstreamCause":                                            CauseKindUpstream,
+	"org.jenkinsci.plugins.workflow.support.steps.build.BuildUpstreamCause":       CauseKindUpstream,
+	"hudson.triggers.TimerTrigger$TimerTriggerCause":                              CauseKindTimer,
+	"org.jenkinsci.plugins.parameterizedscheduler.ParameterizedTimerTriggerCause": CauseKindTimer,
+}
+
+// rawCause is a build cause as returned by the Jenkins API
+type rawCause struct {
+	Class string `json:"_class"`
+	BuildCause
+}
+
+// buildCauses converts raw causes, classifying each by its class
+func buildCauses(raw []rawCause) []BuildCause {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	causes := make([]BuildCause, 0, len(raw))
+	for _, cause := range raw {
+		c := cause.BuildCause
+		c.Kind = CauseKindOther
+		if kind, ok := causeKinds[cause.Class]; ok {
+			c.Kind = kind
+		}
+		causes = append(causes, c)
+	}
+	return causes
+}
+
+// rawChangeSet is a changeset as returned by the Jenkins API
+type rawChangeSet struct {
+	Kind  string `json:"kind"`
+	Items []struct {
+		CommitID string `json:"commitId"`
+		Author   *struct {
+			FullName string `json:"fullName"`
+		} `json:"author"`
+		AuthorEmail   string   `json:"authorEmail"`
+		Msg           string   `json:"msg"`
+		Timestamp     int64    `json:"timestamp"`
+		AffectedPaths []string `json:"affectedPaths"`
+	} `json:"items"`
+}
+
+// changeSet converts a raw changeset
+func (raw rawChangeSet) changeSet() ChangeSet {
+	changeSet := ChangeSet{Kind: raw.Kind, Items: make([]Change, 0, len(raw.Items))}
+	for _, item := range raw.Items {
+		change := Change{
+			CommitID:      item.CommitID,
+			AuthorEmail:   item.AuthorEmail,
+			Message:       item.Msg,
+			Timestamp:     item.Timestamp,
+			AffectedPaths: item.AffectedPaths,
+		}
+		if item.Author != nil {
+			change.Author = item.Author.FullName
+		}
+		changeSet.Items = append(changeSet.Items, change)
+	}
+	return changeSet
+}
+
+// rawBuild is a build as selected by buildTree. Jenkins reports the executor
+// of a running build as an object.
+type rawBuild struct {
+	Build
+	Executor *struct {
+		Number int `json:"number"`
+	} `json:"executor"`
+	Actions []struct {
+		Causes            []rawCause `json:"causes"`
+		LastBuiltRevision *struct {
+			SHA1   string `json:"SHA1"`
+			Branch []struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+		} `json:"lastBuiltRevision"`
+		RemoteURLs []string `json:"remoteUrls"`
+	} `json:"actions"`
+	ChangeSet  *rawChangeSet  `json:"changeSet"`
+	ChangeSets []rawChangeSet `json:"changeSets"`
+	Culprits   []struct {
+		FullName string `json:"fullName"`
+	} `json:"culprits"`
+}
+
+// build converts a raw build. Empty changesets are left out, as are
+// duplicate revisions, which Jenkins records when a repository is checked
+// out more than once.
+func (raw *rawBuild) build() *Build {
+	build := raw.Build
+	if raw.Executor != nil {
+		// Flyweight tasks such as pipeline runs use one-off executors numbered -1
+		build.Executor = fmt.Sprintf("#%d", raw.Executor.Number)
+		if raw.Executor.Number < 0 {
+			build.Executor = "one-off"
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, action := range raw.Actions {
+		build.Causes = append(build.Causes, buildCauses(action.Causes)...)
+
+		if revision := action.LastBuiltRevision; revision != nil && revision.SHA1 != "" {
+			scmRevision := SCMRevision{SHA1: revision.SHA1, RemoteURLs: action.RemoteURLs}
+			for _, branch := range revision.Branch {
+				scmRevision.Branches = append(scmRevision.Branches, branch.Name)
+			}
+			key := fmt.Sprintf("%s %v", scmRevision.SHA1, scmRevision.RemoteURLs)
+			if !seen[key] {
+				seen[key] = true
+				build.Revisions = append(build.Revisions, scmRevision)
+			}
+		}
+	}
+
+	changeSets := raw.ChangeSets
+	if raw.ChangeSet != nil {
+		changeSets = append([]rawChangeSet{*raw.ChangeSet}, changeSets...)
+	}
+	for _, changeSet := range changeSets {
+		if len(changeSet.Items) > 0 {
+			build.ChangeSets = append(build.ChangeSets, changeSet.changeSet())
+		}
+	}
+
+	for _, culprit := range raw.Culprits {
+		build.Culprits = append(build.Culprits, culprit.FullName)
+	}
+
+	return &build
+}
+
+// CompareChanges lists the SCM changes a job's builds picked up after
+// fromBuild up to and including toBuild, i.e. what changed between the two
+// builds. The builds may be given in either order. Builds in the range that
+// were deleted are reported as missing.
+func (c *Client) CompareChanges(ctx context.Context, jobName string, fromBuild, toBuild int) (*ChangeDiff, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if fromBuild <= 0 || toBuild <= 0 {
+		return nil, NewInvalidInputError("build numbers must be positive")
+	}
+	if fromBuild == toBuild {
+		return nil, NewInvalidInputError("fromBuild and toBuild must be different builds")
+	}
+	if fromBuild > toBuild {
+		fromBuild, toBuild = toBuild, fromBuild
+	}
+	if toBuild-fromBuild > maxCompareBuilds {
+		return nil, NewInvalidInputError(fmt.Sprintf("cannot compare more than %d builds at once", maxCompareBuilds))
+	}
+
+	from, err := c.GetBuild(ctx, jobName, fromBuild)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ChangeDiff{
+		JobName:       jobName,
+		FromBuild:     fromBuild,
+		ToBuild:       toBuild,
+		FromRevisions: from.Revisions,
+		Builds:        []BuildChanges{},
+	}
+
+	commits := make(map[string]bool)
+	authors := make(map[string]bool)
+	for number := fromBuild + 1; number <= toBuild; number++ {
+		build, err := c.GetBuild(ctx, jobName, number)
+		if err != nil {
+			// The end of the range must exist; builds in between may have been discarded
+			if code, _ := GetErrorCode(err); code == ErrorCodeNotFound && number < toBuild {
+				diff.Missing = append(diff.Missing, number)
+				continue
+			}
+			return nil, err
+		}
+
+		changes := BuildChanges{Number: build.Number, Result: build.Result, Changes: []Change{}}
+		for _, changeSet := range build.ChangeSets {
+			for _, change := range changeSet.Items {
+				changes.Changes = append(changes.Changes, change)
+				commits[change.CommitID] = true
+				if change.Author != "" {
+					authors[change.Author] = true
+				}
+			}
+		}
+		diff.Builds = append(diff.Builds, changes)
+
+		if number == toBuild {
+			diff.ToRevisions = build.Revisions
+		}
+	}
+
+	diff.Commits = len(commits)
+	for author := range authors {
+		diff.Authors = append(diff.Authors, author)
+	}
+	sort.Strings(diff.Authors)
+
+	return diff, nil
+}
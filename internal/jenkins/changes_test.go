@@ -0,0 +1,192 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pipelineBuild is a pipeline build started by an upstream build with changes
+// from two repositories, of which the shared library was checked out twice
+const pipelineBuild = `{"number":12,"url":"http://jenkins/job/app/12/","result":"FAILURE","building":false,
+	"actions":[
+		{"_class":"hudson.model.CauseAction","causes":[
+			{"_class":"hudson.model.Cause$UpstreamCause","shortDescription":"Started by upstream project \"lib\" build number 7","upstreamProject":"lib","upstreamBuild":7,"upstreamUrl":"job/lib/"},
+			{"_class":"hudson.triggers.TimerTrigger$TimerTriggerCause","shortDescription":"Started by timer"}]},
+		{"_class":"hudson.plugins.git.util.BuildData","lastBuiltRevision":{"SHA1":"abc123","branch":[{"name":"refs/remotes/origin/main"}]},"remoteUrls":["https://git.example.com/app.git"]},
+		{"_class":"hudson.plugins.git.util.BuildData","lastBuiltRevision":{"SHA1":"def456","branch":[{"name":"main"}]},"remoteUrls":["https://git.example.com/lib.git"]},
+		{"_class":"hudson.plugins.git.util.BuildData","lastBuiltRevision":{"SHA1":"def456","branch":[{"name":"main"}]},"remoteUrls":["https://git.example.com/lib.git"]},
+		{}],
+	"changeSets":[
+		{"kind":"git","items":[{"commitId":"abc123","author":{"fullName":"Alice"},"authorEmail":"alice@example.com","msg":"Fix login","timestamp":1700000000000,"affectedPaths":["src/login.go"]}]},
+		{"kind":"git","items":[]}],
+	"culprits":[{"fullName":"Alice"},{"fullName":"Bob"}]}`
+
+func TestGetBuildChanges(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tree := r.URL.Query().Get("tree"); !strings.Contains(tree, "changeSets[") || !strings.Contains(tree, "culprits[") {
+			t.Errorf("tree %q does not select changesets and culprits", tree)
+		}
+		w.Write([]byte(pipelineBuild))
+	}))
+
+	build, err := client.GetBuild(context.Background(), "app", 12)
+	if err != nil {
+		t.Fatalf("GetBuild() error = %v", err)
+	}
+
+	wantCauses := []BuildCause{
+		{Kind: CauseKindUpstream, ShortDescription: `Started by upstream project "lib" build number 7`, UpstreamProject: "lib", UpstreamBuild: 7, UpstreamURL: "job/lib/"},
+		{Kind: CauseKindTimer, ShortDescription: "Started by timer"},
+	}
+	if !reflect.DeepEqual(build.Causes, wantCauses) {
+		t.Errorf("causes = %+v, want %+v", build.Causes, wantCauses)
+	}
+
+	wantChangeSets := []ChangeSet{{Kind: "git", Items: []Change{{
+		CommitID: "abc123", Author: "Alice", AuthorEmail: "alice@example.com", Message: "Fix login",
+		Timestamp: 1700000000000, AffectedPaths: []string{"src/login.go"},
+	}}}}
+	if !reflect.DeepEqual(build.ChangeSets, wantChangeSets) {
+		t.Errorf("changeSets = %+v, want %+v", build.ChangeSets, wantChangeSets)
+	}
+
+	if want := []string{"Alice", "Bob"}; !reflect.DeepEqual(build.Culprits, want) {
+		t.Errorf("culprits = %v, want %v", build.Culprits, want)
+	}
+
+	wantRevisions := []SCMRevision{
+		{SHA1: "abc123", Branches: []string{"refs/remotes/origin/main"}, RemoteURLs: []string{"https://git.example.com/app.git"}},
+		{SHA1: "def456", Branches: []string{"main"}, RemoteURLs: []string{"https://git.example.com/lib.git"}},
+	}
+	if !reflect.DeepEqual(build.Revisions, wantRevisions) {
+		t.Errorf("revisions = %+v, want %+v", build.Revisions, wantRevisions)
+	}
+}
+
+func TestGetRunningBuild(t *testing.T) {
+	tests := []struct {
+		name         string
+		executor     string
+		wantExecutor string
+	}{
+		{name: "executor slot", executor: `{"_class":"hudson.model.Executor","number":1}`, wantExecutor: "#1"},
+		{name: "one-off executor", executor: `{"_class":"hudson.model.OneOffExecutor","number":-1}`, wantExecutor: "one-off"},
+		{name: "finished build", executor: `null`, wantExecutor: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"number":4,"building":true,"estimatedDuration":60000,"executor":%s}`, tt.executor)
+			}))
+
+			build, err := client.GetBuild(context.Background(), "app", 4)
+			if err != nil {
+				t.Fatalf("GetBuild() error = %v", err)
+			}
+			if build.Executor != tt.wantExecutor || !build.Building {
+				t.Errorf("executor = %q, building = %v, want %q, true", build.Executor, build.Building, tt.wantExecutor)
+			}
+		})
+	}
+}
+
+func TestGetLatestBuildChanges(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Freestyle builds report a single changeSet
+		w.Write([]byte(`{"lastBuild":{"number":3,"actions":[{"causes":[{"_class":"hudson.model.Cause$UserIdCause","shortDescription":"Started by user Alice","userId":"alice"}]}],
+			"changeSet":{"kind":"git","items":[{"commitId":"c1","msg":"Bump version"}]}}}`))
+	}))
+
+	build, err := client.GetLatestBuild(context.Background(), "freestyle")
+	if err != nil {
+		t.Fatalf("GetLatestBuild() error = %v", err)
+	}
+
+	if len(build.Causes) != 1 || build.Causes[0].Kind != CauseKindUser || build.Causes[0].UserID != "alice" {
+		t.Errorf("causes = %+v, want the user cause", build.Causes)
+	}
+	if len(build.ChangeSets) != 1 || build.ChangeSets[0].Items[0].CommitID != "c1" {
+		t.Errorf("changeSets = %+v, want the freestyle changeSet", build.ChangeSets)
+	}
+}
+
+// changeHistoryHandler serves builds 1 to 5 of job app; build n has one commit
+// "cn" by author n%2, and build 3 was deleted
+func changeHistoryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var number int
+		if _, err := fmt.Sscanf(r.URL.Path, "/job/app/%d/api/json", &number); err != nil || number < 1 || number > 5 || number == 3 {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"number":%d,"result":"SUCCESS",
+			"actions":[{"lastBuiltRevision":{"SHA1":"sha%d"},"remoteUrls":["https://git.example.com/app.git"]}],
+			"changeSets":[{"kind":"git","items":[{"commitId":"c%d","author":{"fullName":"author%d"},"msg":"change %d"}]}]}`,
+			number, number, number, number%2, number)
+	})
+}
+
+func TestCompareChanges(t *testing.T) {
+	client := newTestClient(t, changeHistoryHandler())
+	ctx := context.Background()
+
+	// The builds may be given newest first
+	diff, err := client.CompareChanges(ctx, "app", 5, 1)
+	if err != nil {
+		t.Fatalf("CompareChanges() error = %v", err)
+	}
+
+	if diff.FromBuild != 1 || diff.ToBuild != 5 {
+		t.Errorf("range = %d..%d, want 1..5", diff.FromBuild, diff.ToBuild)
+	}
+	var numbers []int
+	var commits []string
+	for _, build := range diff.Builds {
+		numbers = append(numbers, build.Number)
+		for _, change := range build.Changes {
+			commits = append(commits, change.CommitID)
+		}
+	}
+	if want := []int{2, 4, 5}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("builds = %v, want %v", numbers, want)
+	}
+	if want := []string{"c2", "c4", "c5"}; !reflect.DeepEqual(commits, want) {
+		t.Errorf("commits = %v, want %v", commits, want)
+	}
+	if diff.Commits != 3 {
+		t.Errorf("commit count = %d, want 3", diff.Commits)
+	}
+	if want := []string{"author0", "author1"}; !reflect.DeepEqual(diff.Authors, want) {
+		t.Errorf("authors = %v, want %v", diff.Authors, want)
+	}
+	if want := []int{3}; !reflect.DeepEqual(diff.Missing, want) {
+		t.Errorf("missing = %v, want %v", diff.Missing, want)
+	}
+	if diff.FromRevisions[0].SHA1 != "sha1" || diff.ToRevisions[0].SHA1 != "sha5" {
+		t.Errorf("revisions = %+v -> %+v, want sha1 -> sha5", diff.FromRevisions, diff.ToRevisions)
+	}
+
+	tests := []struct {
+		name     string
+		from, to int
+		wantCode ErrorCode
+	}{
+		{name: "same build", from: 2, to: 2, wantCode: ErrorCodeInvalidInput},
+		{name: "range too large", from: 1, to: 1 + maxCompareBuilds + 1, wantCode: ErrorCodeInvalidInput},
+		{name: "missing end build", from: 1, to: 3, wantCode: ErrorCodeNotFound},
+		{name: "missing start build", from: 3, to: 5, wantCode: ErrorCodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CompareChanges(ctx, "app", tt.from, tt.to)
+			if code, _ := GetErrorCode(err); code != tt.wantCode {
+				t.Errorf("CompareChanges(%d, %d) error = %v, want %s", tt.from, tt.to, err, tt.wantCode)
+			}
+		})
+	}
+}
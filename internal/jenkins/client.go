@@ -13,33 +13,62 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/metrics"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
 	_ "github.com/leanovate/gopter" // Will be used for property-based testing
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // JenkinsClient defines the interface for interacting with Jenkins API
 type JenkinsClient interface {
 	// Job operations
 	ListJobs(ctx context.Context, folder string) ([]Job, error)
+	ListJobsRecursive(ctx context.Context, folder string) ([]Job, error)
 	GetJob(ctx context.Context, jobName string) (*JobDetails, error)
 
+	// Job configuration operations
+	GetJobConfig(ctx context.Context, jobName string) (string, error)
+	CreateJob(ctx context.Context, jobName string, configXML string) error
+	CopyJob(ctx context.Context, fromJob string, toJob string) error
+	UpdateJobConfig(ctx context.Context, jobName string, configXML string) error
+	SetJobEnabled(ctx context.Context, jobName string, enabled bool) error
+	RenameJob(ctx context.Context, jobName string, newName string) error
+	DeleteJob(ctx context.Context, jobName string) error
+
 	// Build operations
 	TriggerBuild(ctx context.Context, jobName string, params map[string]string) (*QueueItem, error)
+	TriggerBuildWithFiles(ctx context.Context, jobName string, params map[string]string, files map[string]BuildFile) (*QueueItem, error)
 	GetBuild(ctx context.Context, jobName string, buildNumber int) (*Build, error)
 	GetLatestBuild(ctx context.Context, jobName string) (*Build, error)
+	ListBuilds(ctx context.Context, jobName string, opts ListBuildsOptions) (*BuildPage, error)
 	StopBuild(ctx context.Context, jobName string, buildNumber int) error
+	CompareChanges(ctx context.Context, jobName string, fromBuild, toBuild int) (*ChangeDiff, error)
+
+	// Rebuild operations
+	GetBuildInputs(ctx context.Context, jobName string, buildNumber int) (*BuildInputs, error)
+	RebuildBuild(ctx context.Context, jobName string, buildNumber int, overrides map[string]string) (*Rebuild, error)
+	GetReplayScripts(ctx context.Context, jobName string, buildNumber int) (*ReplayScripts, error)
+	ReplayBuild(ctx context.Context, jobName string, buildNumber int, mainScript string, loadedScripts map[string]string) (*Rebuild, error)
 
 	// Log and artifact operations
 	GetBuildLog(ctx context.Context, jobName string, buildNumber int) (string, error)
+	GetBuildLogProgressive(ctx context.Context, jobName string, buildNumber int, start int64, tailLines int) (*LogChunk, error)
 	ListArtifacts(ctx context.Context, jobName string, buildNumber int) ([]Artifact, error)
 	GetArtifact(ctx context.Context, jobName string, buildNumber int, artifactPath string) ([]byte, error)
 
 	// Queue operations
 	GetQueue(ctx context.Context) ([]QueueItem, error)
 	GetQueueItem(ctx context.Context, queueID int) (*QueueItem, error)
+	WaitForBuild(ctx context.Context, jobName string, queueID int, opts WaitOptions) (*Build, error)
 	CancelQueueItem(ctx context.Context, queueID int) error
 
 	// Running builds operations
@@ -50,7 +79,27 @@ type JenkinsClient interface {
 	GetView(ctx context.Context, viewName string) (*ViewDetails, error)
 	CreateView(ctx context.Context, viewName string, viewType string) error
 	GetNodes(ctx context.Context) ([]Node, error)
+	GetNode(ctx context.Context, nodeName string) (*Node, error)
+	SetNodeOffline(ctx context.Context, nodeName string, offline bool, reason string) error
 	GetPipelineScript(ctx context.Context, jobName string) (string, error)
+
+	// Pipeline stage operations
+	GetPipelineRun(ctx context.Context, jobName string, buildNumber int) (*PipelineRun, error)
+	GetStageLog(ctx context.Context, jobName string, buildNumber int, stageID string) (*StageLog, error)
+
+	// Test report operations
+	GetTestReport(ctx context.Context, jobName string, buildNumber int) (*TestReport, error)
+
+	// Diagnosis operations
+	DiagnoseBuild(ctx context.Context, jobName string, buildNumber int, opts DiagnoseOptions) (*BuildDiagnosis, error)
+
+	// Multibranch operations
+	ListBranches(ctx context.Context, jobName string) ([]Branch, error)
+	ScanMultibranch(ctx context.Context, jobName string) error
+	GetBranchSources(ctx context.Context, jobName string) (*MultibranchConfig, error)
+
+	// CacheStats returns the response cache counters, or nil when caching is disabled
+	CacheStats() *CacheStats
 }
 
 // Client represents a Jenkins API client implementation
@@ -62,6 +111,8 @@ type Client struct {
 	apiToken   string
 	maxRetries int
 	backoff    time.Duration
+	cache      *responseCache
+	instance   string
 }
 
 // retryTransport implements http.RoundTripper with retry logic and exponential backoff
@@ -69,24 +120,32 @@ type retryTransport struct {
 	transport  http.RoundTripper
 	maxRetries int
 	backoff    time.Duration
+	instance   string
 }
 
 // RoundTrip executes a single HTTP transaction with retry logic
 func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointFromContext(req.Context())
+
 	// Only retry idempotent operations (GET requests)
 	if req.Method != http.MethodGet {
-		return rt.transport.RoundTrip(req)
+		return rt.roundTrip(req, endpoint, 0)
 	}
 
 	var lastErr error
 	for attempt := 0; attempt <= rt.maxRetries; attempt++ {
+		if attempt > 0 {
+			metrics.IncJenkinsRetry(rt.instance, endpoint)
+		}
+
 		// Clone the request for retry attempts
 		reqClone := req.Clone(req.Context())
 
-		resp, err := rt.transport.RoundTrip(reqClone)
+		resp, err := rt.roundTrip(reqClone, endpoint, attempt)
 
-		// Success - return immediately
-		if err == nil && resp.StatusCode < 500 {
+		// Success - return immediately. The last server error response is
+		// returned as-is so callers can report its status code.
+		if err == nil && (resp.StatusCode < 500 || attempt == rt.maxRetries) {
 			return resp, nil
 		}
 
@@ -110,6 +169,41 @@ func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// roundTrip sends a single request attempt in its own client span, passing
+// the trace context on to Jenkins, and records its outcome
+func (rt *retryTransport) roundTrip(req *http.Request, endpoint string, attempt int) (*http.Response, error) {
+	ctx, span := tracing.Tracer().Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(redact.String(req.URL.Redacted())),
+			tracing.EndpointKey.String(endpoint),
+		))
+	defer span.End()
+	if attempt > 0 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(attempt))
+	}
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	resp, err := rt.transport.RoundTrip(req)
+
+	code := ""
+	if err != nil {
+		reqErr := NewRequestError(req, err)
+		code = string(reqErr.Code)
+		tracing.RecordError(span, err, code)
+	} else {
+		code = strconv.Itoa(resp.StatusCode)
+		recordSpanResponse(span, resp)
+	}
+	metrics.ObserveJenkinsRequest(rt.instance, endpoint, req.Method, code, time.Since(start))
+
+	return resp, err
+}
+
 // NewClient creates a new Jenkins client with the provided configuration
 func NewClient(cfg *config.Config) (JenkinsClient, error) {
 	if cfg == nil {
@@ -132,6 +226,7 @@ func NewClient(cfg *config.Config) (JenkinsClient, error) {
 		transport:  transport,
 		maxRetries: cfg.MaxRetries,
 		backoff:    cfg.RetryBackoff,
+		instance:   cfg.DefaultInstance(),
 	}
 
 	// Create HTTP client with timeout and custom transport
@@ -148,6 +243,8 @@ func NewClient(cfg *config.Config) (JenkinsClient, error) {
 		apiToken:   cfg.APIToken,
 		maxRetries: cfg.MaxRetries,
 		backoff:    cfg.RetryBackoff,
+		cache:      newResponseCache(cfg.Cache),
+		instance:   cfg.DefaultInstance(),
 	}
 
 	return client, nil
@@ -172,7 +269,7 @@ func createTransport(cfg *config.Config) (*http.Transport, error) {
 		if cfg.CACertPath != "" {
 			caCert, err := os.ReadFile(cfg.CACertPath)
 			if err != nil {
-				return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+				return nil, WrapError(ErrorCodeJenkinsError, "failed to read CA certificate", err)
 			}
 
 			caCertPool := x509.NewCertPool()
@@ -203,11 +300,23 @@ func (c *Client) addAuthentication(req *http.Request) {
 
 // getCrumb fetches a CSRF crumb from Jenkins
 func (c *Client) getCrumb(ctx context.Context) (string, string, error) {
-	url := c.baseURL + "/crumbIssuer/api/json"
+	ctx, span := c.startRequestSpan(ctx, http.MethodGet, crumbIssuerPath, crumbEndpoint)
+	defer span.End()
+
+	crumbField, crumb, err := c.fetchCrumb(ctx)
+	if err != nil {
+		recordSpanError(span, err)
+	}
+	return crumbField, crumb, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchCrumb requests a CSRF crumb from the crumb issuer
+func (c *Client) fetchCrumb(ctx context.Context) (string, string, error) {
+	url := c.baseURL + crumbIssuerPath
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, crumbEndpoint), http.MethodGet, url, nil)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create crumb request: %w", err)
+		return "", "", WrapError(ErrorCodeInternalError, "failed to create crumb request", err)
 	}
 
 	// Add authentication
@@ -217,7 +326,7 @@ func (c *Client) getCrumb(ctx context.Context) (string, string, error) {
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", "", fmt.Errorf("crumb request failed: %w", err)
+		return "", "", NewRequestError(req, err)
 	}
 	defer resp.Body.Close()
 
@@ -227,7 +336,7 @@ func (c *Client) getCrumb(ctx context.Context) (string, string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", "", fmt.Errorf("unexpected status code %d when fetching crumb", resp.StatusCode)
+		return "", "", NewHTTPError(resp, "CSRF crumb")
 	}
 
 	// Parse crumb response
@@ -238,11 +347,11 @@ func (c *Client) getCrumb(ctx context.Context) (string, string, error) {
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to read crumb response: %w", err)
+		return "", "", WrapError(ErrorCodeJenkinsError, "failed to read crumb response", err)
 	}
 
 	if err := json.Unmarshal(body, &crumbData); err != nil {
-		return "", "", fmt.Errorf("failed to parse crumb response: %w", err)
+		return "", "", WrapError(ErrorCodeJenkinsError, "failed to parse crumb response", err)
 	}
 
 	return crumbData.CrumbRequestField, crumbData.Crumb, nil
@@ -250,11 +359,22 @@ func (c *Client) getCrumb(ctx context.Context) (string, string, error) {
 
 // doRequest executes an HTTP request with authentication and context
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return c.doRequestWithContentType(ctx, method, path, body, "application/json")
+}
+
+// doRequestWithContentType executes an HTTP request whose body has the given content type
+func (c *Client) doRequestWithContentType(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
 	url := c.baseURL + path
+	endpoint := requestEndpoint(method, path)
 
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	ctx, span := c.startRequestSpan(ctx, method, path, endpoint)
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), method, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		err = WrapError(ErrorCodeInternalError, "failed to create request", err)
+		recordSpanError(span, err)
+		return nil, err
 	}
 
 	// Add authentication
@@ -263,60 +383,74 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	// Set common headers
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	// For POST requests, fetch and add CSRF crumb
 	if method == http.MethodPost {
 		crumbField, crumb, err := c.getCrumb(ctx)
 		if err != nil {
-			// Log the error but continue - some Jenkins instances don't have CSRF protection
-			// In production, you might want to handle this differently
+			// Count the failure but continue - some Jenkins instances don't have CSRF protection
+			metrics.IncCrumbFailure(c.instance)
 		} else if crumb != "" {
 			// Add the crumb header
 			req.Header.Set(crumbField, crumb)
 		}
 	}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	// Execute request, serving reads from the response cache when enabled
+	var resp *http.Response
+	if c.cache != nil && method == http.MethodGet && !cacheBypassed(ctx) {
+		resp, err = c.cache.fetch(path, req, c.httpClient.Do)
+	} else {
+		resp, err = c.httpClient.Do(req)
+	}
+
+	// A mutation may have succeeded even if its response was lost
+	if c.cache != nil && method != http.MethodGet {
+		c.cache.invalidate(path)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		reqErr := NewRequestError(req, err)
+		recordSpanError(span, reqErr)
+		return nil, reqErr
 	}
 
+	recordSpanResponse(span, resp)
 	return resp, nil
 }
 
-// Placeholder implementations for interface methods
-// These will be implemented in subsequent tasks
+// CacheStats returns the response cache counters, or nil when caching is disabled
+func (c *Client) CacheStats() *CacheStats {
+	if c.cache == nil {
+		return nil
+	}
+	stats := c.cache.Stats()
+	return &stats
+}
+
+// maxFolderDepth bounds how deep ListJobsRecursive descends into nested folders
+const maxFolderDepth = 10
 
+// ListJobs lists the jobs directly inside the given folder (or the root when folder is empty)
 func (c *Client) ListJobs(ctx context.Context, folder string) ([]Job, error) {
 	// Build the API path
-	path := "/api/json"
-	if folder != "" {
-		path = "/job/" + folder + path
-	}
+	path := jobPath(folder) + "/api/json"
 
 	// Add tree parameter to get specific job fields
-	path += "?tree=jobs[name,url,description,buildable,inQueue,color]"
+	path += "?tree=jobs[_class,name,fullName,url,description,buildable,inQueue,color]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list jobs: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("folder not found: %s", folder)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to list jobs")
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("folder %s", folder))
 	}
 
 	// Parse response
@@ -326,11 +460,11 @@ func (c *Client) ListJobs(ctx context.Context, folder string) ([]Job, error) {
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Return empty list if no jobs (not an error)
@@ -338,50 +472,82 @@ func (c *Client) ListJobs(ctx context.Context, folder string) ([]Job, error) {
 		return []Job{}, nil
 	}
 
+	// Older Jenkins versions omit fullName; derive it from the folder path
+	for i := range result.Jobs {
+		if result.Jobs[i].FullName == "" {
+			result.Jobs[i].FullName = strings.Trim(folder+"/"+result.Jobs[i].Name, "/")
+		}
+	}
+
 	return result.Jobs, nil
 }
 
+// ListJobsRecursive lists all jobs under the given folder, descending into nested
+// folders, organization folders and multibranch projects
+func (c *Client) ListJobsRecursive(ctx context.Context, folder string) ([]Job, error) {
+	return c.listJobsRecursive(ctx, folder, 0)
+}
+
+func (c *Client) listJobsRecursive(ctx context.Context, folder string, depth int) ([]Job, error) {
+	jobs, err := c.ListJobs(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	allJobs := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		allJobs = append(allJobs, job)
+
+		if !isFolder(job.Class) || depth+1 >= maxFolderDepth {
+			continue
+		}
+
+		children, err := c.listJobsRecursive(ctx, job.FullName, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		allJobs = append(allJobs, children...)
+	}
+
+	return allJobs, nil
+}
+
 func (c *Client) GetJob(ctx context.Context, jobName string) (*JobDetails, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 
 	// Build the API path with detailed tree parameter
-	path := fmt.Sprintf("/job/%s/api/json", jobName)
-	path += "?tree=name,url,description,buildable,inQueue,color,disabled,"
+	path := jobPath(jobName) + "/api/json"
+	path += "?tree=_class,name,fullName,url,description,buildable,inQueue,color,disabled,"
 	path += "lastBuild[number,url],"
 	path += "lastSuccessfulBuild[number,url],"
 	path += "lastFailedBuild[number,url],"
-	path += "property[parameterDefinitions[name,type,defaultParameterValue[value],description]]"
+	path += "property[parameterDefinitions[name,type,defaultParameterValue[value],description,choices]]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get job details: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("job not found: %s", jobName)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
 	}
 
 	// Parse response - Jenkins returns a complex structure for parameters
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	// First parse into a raw structure to handle Jenkins' nested parameter format
 	var rawResult struct {
+		Class               string          `json:"_class"`
 		Name                string          `json:"name"`
+		FullName            string          `json:"fullName"`
 		URL                 string          `json:"url"`
 		Description         string          `json:"description"`
 		Buildable           bool            `json:"buildable"`
@@ -393,9 +559,10 @@ func (c *Client) GetJob(ctx context.Context, jobName string) (*JobDetails, error
 		LastFailedBuild     *BuildReference `json:"lastFailedBuild"`
 		Property            []struct {
 			ParameterDefinitions []struct {
-				Name                  string `json:"name"`
-				Type                  string `json:"type"`
-				Description           string `json:"description"`
+				Name                  string   `json:"name"`
+				Type                  string   `json:"type"`
+				Description           string   `json:"description"`
+				Choices               []string `json:"choices"`
 				DefaultParameterValue struct {
 					Value interface{} `json:"value"`
 				} `json:"defaultParameterValue"`
@@ -404,13 +571,15 @@ func (c *Client) GetJob(ctx context.Context, jobName string) (*JobDetails, error
 	}
 
 	if err := json.Unmarshal(body, &rawResult); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Build JobDetails from raw result
 	jobDetails := &JobDetails{
 		Job: Job{
+			Class:       rawResult.Class,
 			Name:        rawResult.Name,
+			FullName:    rawResult.FullName,
 			URL:         rawResult.URL,
 			Description: rawResult.Description,
 			Buildable:   rawResult.Buildable,
@@ -430,8 +599,13 @@ func (c *Client) GetJob(ctx context.Context, jobName string) (*JobDetails, error
 			param := JobParameter{
 				Name:         paramDef.Name,
 				Type:         paramDef.Type,
+				Kind:         parameterKind(paramDef.Type),
 				Description:  paramDef.Description,
 				DefaultValue: paramDef.DefaultParameterValue.Value,
+				Choices:      paramDef.Choices,
+			}
+			if param.IsSecret() && param.DefaultValue != nil && param.DefaultValue != "" {
+				param.DefaultValue = redact.Mask
 			}
 			jobDetails.Parameters = append(jobDetails.Parameters, param)
 		}
@@ -441,30 +615,56 @@ func (c *Client) GetJob(ctx context.Context, jobName string) (*JobDetails, error
 }
 
 func (c *Client) TriggerBuild(ctx context.Context, jobName string, params map[string]string) (*QueueItem, error) {
+	return c.TriggerBuildWithFiles(ctx, jobName, params, nil)
+}
+
+// TriggerBuildWithFiles triggers a build with parameter values and files for
+// its file parameters. Values are validated against the job's parameter
+// definitions; when files are uploaded all parameters are sent as a
+// multipart form.
+func (c *Client) TriggerBuildWithFiles(ctx context.Context, jobName string, params map[string]string, files map[string]BuildFile) (*QueueItem, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 
 	// First, get job details to validate parameters
-	jobDetails, err := c.GetJob(ctx, jobName)
+	jobDetails, err := c.GetJob(withoutCache(ctx), jobName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get job details for validation: %w", err)
+		return nil, err
 	}
 
+	return c.triggerBuild(ctx, jobDetails, jobName, params, files)
+}
+
+// triggerBuild validates the parameters against the given job details and
+// queues the build
+func (c *Client) triggerBuild(ctx context.Context, jobDetails *JobDetails, jobName string, params map[string]string, files map[string]BuildFile) (*QueueItem, error) {
+	var err error
+
 	// Validate parameters against job definition
-	if len(params) > 0 {
-		if err := c.validateParameters(jobDetails, params); err != nil {
-			return nil, fmt.Errorf("parameter validation failed: %w", err)
+	if len(params) > 0 || len(files) > 0 {
+		params, err = c.validateParameters(jobDetails, params, files)
+		if err != nil {
+			return nil, err
 		}
+		RegisterSecretParameters(jobDetails, params)
 	}
 
 	// Determine the endpoint based on whether parameters are provided
 	var path string
 	var body io.Reader
+	contentType := "application/json"
 
-	if len(params) > 0 {
+	if len(files) > 0 {
+		// Files are uploaded together with the values as a multipart form
+		path = jobPath(jobName) + "/buildWithParameters"
+		body, contentType, err = multipartParameters(params, uploadedFiles(jobDetails, files))
+		if err != nil {
+			return nil, WrapError(ErrorCodeInternalError, "failed to encode file parameters", err)
+		}
+	} else if len(params) > 0 {
 		// Use buildWithParameters endpoint with query parameters
-		path = fmt.Sprintf("/job/%s/buildWithParameters", jobName)
+		path = jobPath(jobName) + "/buildWithParameters"
 
 		// Jenkins expects parameters as query parameters in the URL
 		queryParams := url.Values{}
@@ -474,31 +674,24 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string, params map[st
 		path = path + "?" + queryParams.Encode()
 	} else {
 		// Use simple build endpoint
-		path = fmt.Sprintf("/job/%s/build", jobName)
+		path = jobPath(jobName) + "/build"
 	}
 
 	// Make POST request
-	resp, err := c.doRequest(ctx, http.MethodPost, path, body)
+	resp, err := c.doRequestWithContentType(ctx, http.MethodPost, path, body, contentType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to trigger build: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("job not found: %s", jobName)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to trigger build for job %s", jobName)
-	}
-
 	// Handle redirects (302, 303, 307, 308) and success codes (201, 200)
 	var location string
 	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
 		// Redirect response - get location header
 		location = resp.Header.Get("Location")
 		if location == "" {
-			return nil, fmt.Errorf("redirect received but no Location header present (status: %d)", resp.StatusCode)
+			return nil, NewErrorWithDetails(ErrorCodeJenkinsError, "redirect received but no Location header present",
+				map[string]interface{}{"status_code": resp.StatusCode})
 		}
 	} else if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK {
 		// Success response - try to get location header
@@ -509,7 +702,7 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string, params map[st
 			// For now, we'll generate a queue location based on response
 			location = c.generateQueueLocationFromResponse(jobName, resp)
 			if location == "" {
-				return nil, fmt.Errorf(
+				return nil, NewJenkinsError(
 					"jenkins did not return a queue Location header. " +
 						"This usually happens when:\n" +
 						" - Authentication failed (MFA enforced)\n" +
@@ -522,15 +715,14 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string, params map[st
 			}
 		}
 	} else {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, NewHTTPError(resp, fmt.Sprintf("build trigger of job %s", jobName))
 	}
 
 	// Extract queue item ID from location URL
 	// Location format: http://jenkins.example.com/queue/item/{id}/
 	queueID, err := c.parseQueueIDFromLocation(location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse queue ID from location: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse queue ID from location", err)
 	}
 
 	// Return queue item with the ID
@@ -539,30 +731,19 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string, params map[st
 		JobName: jobName,
 	}
 
-	// If parameters were provided, include them in the response
-	if len(params) > 0 {
-		queueItem.Parameters = params
-	}
-
-	return queueItem, nil
-}
-
-// validateParameters validates the provided parameters against the job definition
-func (c *Client) validateParameters(jobDetails *JobDetails, params map[string]string) error {
-	// Build a map of valid parameter names from job definition
-	validParams := make(map[string]JobParameter)
-	for _, param := range jobDetails.Parameters {
-		validParams[param.Name] = param
-	}
-
-	// Check if all provided parameters are valid
-	for paramName := range params {
-		if _, exists := validParams[paramName]; !exists {
-			return fmt.Errorf("invalid parameter: %s is not defined for this job", paramName)
+	// If parameters were provided, include them in the response with the
+	// values of password parameters masked and files listed by name
+	if len(params) > 0 || len(files) > 0 {
+		queueItem.Parameters = maskSecretParameters(jobDetails, params)
+		for name, file := range files {
+			if file.FileName == "" {
+				file.FileName = name
+			}
+			queueItem.Parameters[name] = fmt.Sprintf("file %s (%d bytes)", file.FileName, len(file.Content))
 		}
 	}
 
-	return nil
+	return queueItem, nil
 }
 
 // parseQueueIDFromLocation extracts the queue item ID from the Location header
@@ -636,137 +817,116 @@ func (c *Client) generateQueueLocationFromResponse(_ string, resp *http.Response
 
 func (c *Client) GetBuild(ctx context.Context, jobName string, buildNumber int) (*Build, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 	if buildNumber <= 0 {
-		return nil, fmt.Errorf("build number must be positive")
+		return nil, NewInvalidInputError("build number must be positive")
 	}
 
 	// Build the API path with tree parameter to get specific build fields
-	path := fmt.Sprintf("/job/%s/%d/api/json", jobName, buildNumber)
-	path += "?tree=number,url,result,building,duration,timestamp,executor,estimatedDuration"
+	path := fmt.Sprintf("%s/%d/api/json", jobPath(jobName), buildNumber)
+	path += "?tree=" + buildTree
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get build: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("build not found: job=%s, build=%d", jobName, buildNumber)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access build for job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("build %s #%d", jobName, buildNumber))
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
-	var build Build
+	var build rawBuild
 	if err := json.Unmarshal(body, &build); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
-	return &build, nil
+	return build.build(), nil
 }
 
 func (c *Client) GetLatestBuild(ctx context.Context, jobName string) (*Build, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 
 	// Build the API path to get the lastBuild information
-	path := fmt.Sprintf("/job/%s/api/json", jobName)
-	path += "?tree=lastBuild[number,url,result,building,duration,timestamp,executor,estimatedDuration]"
+	path := jobPath(jobName) + "/api/json"
+	path += "?tree=lastBuild[" + buildTree + "]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest build: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("job not found: %s", jobName)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var result struct {
-		LastBuild *Build `json:"lastBuild"`
+		LastBuild *rawBuild `json:"lastBuild"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Check if there is a last build
 	if result.LastBuild == nil {
-		return nil, fmt.Errorf("no builds found for job: %s", jobName)
+		return nil, NewNotFoundError(fmt.Sprintf("builds of job %s", jobName))
 	}
 
-	return result.LastBuild, nil
+	return result.LastBuild.build(), nil
 }
 
 func (c *Client) StopBuild(ctx context.Context, jobName string, buildNumber int) error {
 	if jobName == "" {
-		return fmt.Errorf("job name cannot be empty")
+		return NewInvalidInputError("job name cannot be empty")
 	}
 	if buildNumber <= 0 {
-		return fmt.Errorf("build number must be positive")
+		return NewInvalidInputError("build number must be positive")
 	}
 
 	// First, check if the build exists and is running
 	build, err := c.GetBuild(ctx, jobName, buildNumber)
 	if err != nil {
-		return fmt.Errorf("failed to get build status: %w", err)
+		return err
 	}
 
 	// Check if the build is already completed
 	if !build.Building {
-		return fmt.Errorf("build is not running: job=%s, build=%d, status=%s", jobName, buildNumber, build.Result)
+		return NewInvalidInputError(fmt.Sprintf("build is not running: job=%s, build=%d, status=%s", jobName, buildNumber, build.Result))
 	}
 
 	// Build the API path for stopping the build
-	path := fmt.Sprintf("/job/%s/%d/stop", jobName, buildNumber)
+	path := fmt.Sprintf("%s/%d/stop", jobPath(jobName), buildNumber)
 
 	// Make POST request to stop the build
 	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return fmt.Errorf("failed to stop build: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("build not found: job=%s, build=%d", jobName, buildNumber)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("permission denied: insufficient permissions to stop build for job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return NewHTTPError(resp, fmt.Sprintf("build %s #%d", jobName, buildNumber))
 	}
 
 	// Verify the build status has been updated to aborted
@@ -775,17 +935,17 @@ func (c *Client) StopBuild(ctx context.Context, jobName string, buildNumber int)
 
 	updatedBuild, err := c.GetBuild(ctx, jobName, buildNumber)
 	if err != nil {
-		return fmt.Errorf("failed to verify build status after stop: %w", err)
+		return WrapError(ErrorCodeJenkinsError, "failed to verify build status after stop", err)
 	}
 
 	// Check if the build was successfully stopped
 	if updatedBuild.Building {
-		return fmt.Errorf("build is still running after stop request: job=%s, build=%d", jobName, buildNumber)
+		return NewJenkinsError(fmt.Sprintf("build is still running after stop request: job=%s, build=%d", jobName, buildNumber))
 	}
 
 	// Verify the result is ABORTED
 	if updatedBuild.Result != "ABORTED" {
-		return fmt.Errorf("build status is %s, expected ABORTED: job=%s, build=%d", updatedBuild.Result, jobName, buildNumber)
+		return NewJenkinsError(fmt.Sprintf("build status is %s, expected ABORTED: job=%s, build=%d", updatedBuild.Result, jobName, buildNumber))
 	}
 
 	return nil
@@ -800,35 +960,28 @@ func (c *Client) GetBuildLog(ctx context.Context, jobName string, buildNumber in
 // If sizeLimit > 0, only the first sizeLimit bytes are retrieved
 func (c *Client) GetBuildLogWithLimit(ctx context.Context, jobName string, buildNumber int, sizeLimit int64) (string, error) {
 	if jobName == "" {
-		return "", fmt.Errorf("job name cannot be empty")
+		return "", NewInvalidInputError("job name cannot be empty")
 	}
 	if buildNumber <= 0 {
-		return "", fmt.Errorf("build number must be positive")
+		return "", NewInvalidInputError("build number must be positive")
 	}
 	if sizeLimit < 0 {
-		return "", fmt.Errorf("size limit must be non-negative")
+		return "", NewInvalidInputError("size limit must be non-negative")
 	}
 
 	// Build the API path for console text
-	path := fmt.Sprintf("/job/%s/%d/consoleText", jobName, buildNumber)
+	path := fmt.Sprintf("%s/%d/consoleText", jobPath(jobName), buildNumber)
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to get build log: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("build log not found: job=%s, build=%d", jobName, buildNumber)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return "", fmt.Errorf("permission denied: insufficient permissions to access build log for job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return "", NewHTTPError(resp, fmt.Sprintf("build log %s #%d", jobName, buildNumber))
 	}
 
 	// Read the log content with optional size limit
@@ -843,7 +996,7 @@ func (c *Client) GetBuildLogWithLimit(ctx context.Context, jobName string, build
 	}
 
 	if err != nil {
-		return "", fmt.Errorf("failed to read log content: %w", err)
+		return "", WrapError(ErrorCodeJenkinsError, "failed to read log content", err)
 	}
 
 	// Return the log as a string, preserving all formatting including line breaks and ANSI codes
@@ -853,39 +1006,32 @@ func (c *Client) GetBuildLogWithLimit(ctx context.Context, jobName string, build
 
 func (c *Client) ListArtifacts(ctx context.Context, jobName string, buildNumber int) ([]Artifact, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 	if buildNumber <= 0 {
-		return nil, fmt.Errorf("build number must be positive")
+		return nil, NewInvalidInputError("build number must be positive")
 	}
 
 	// Build the API path with artifacts tree parameter
-	path := fmt.Sprintf("/job/%s/%d/api/json", jobName, buildNumber)
-	path += "?tree=artifacts[fileName,relativePath,size]"
+	path := fmt.Sprintf("%s/%d/api/json", jobPath(jobName), buildNumber)
+	path += "?tree=building,artifacts[fileName,relativePath,size]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("build not found: job=%s, build=%d", jobName, buildNumber)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access artifacts for job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("build %s #%d", jobName, buildNumber))
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var result struct {
@@ -893,7 +1039,7 @@ func (c *Client) ListArtifacts(ctx context.Context, jobName string, buildNumber
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Return empty list if no artifacts (not an error)
@@ -906,42 +1052,35 @@ func (c *Client) ListArtifacts(ctx context.Context, jobName string, buildNumber
 
 func (c *Client) GetArtifact(ctx context.Context, jobName string, buildNumber int, artifactPath string) ([]byte, error) {
 	if jobName == "" {
-		return nil, fmt.Errorf("job name cannot be empty")
+		return nil, NewInvalidInputError("job name cannot be empty")
 	}
 	if buildNumber <= 0 {
-		return nil, fmt.Errorf("build number must be positive")
+		return nil, NewInvalidInputError("build number must be positive")
 	}
 	if artifactPath == "" {
-		return nil, fmt.Errorf("artifact path cannot be empty")
+		return nil, NewInvalidInputError("artifact path cannot be empty")
 	}
 
 	// Build the API path for artifact download
-	path := fmt.Sprintf("/job/%s/%d/artifact/%s", jobName, buildNumber, artifactPath)
+	path := fmt.Sprintf("%s/%d/artifact/%s", jobPath(jobName), buildNumber, escapePath(artifactPath))
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get artifact: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("artifact not found: job=%s, build=%d, path=%s", jobName, buildNumber, artifactPath)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access artifact for job %s", jobName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("artifact %s of build %s #%d", artifactPath, jobName, buildNumber))
 	}
 
 	// Read artifact content efficiently
 	// For large artifacts, this uses streaming internally via io.ReadAll
 	artifactData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read artifact content: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read artifact content", err)
 	}
 
 	return artifactData, nil
@@ -950,28 +1089,24 @@ func (c *Client) GetArtifact(ctx context.Context, jobName string, buildNumber in
 func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
 	// Build the API path with tree parameter to get specific queue fields
 	path := "/queue/api/json"
-	path += "?tree=items[id,task[name],why,blocked,buildable,stuck,inQueueSince,params]"
+	path += "?tree=items[id,task[name,url],why,blocked,buildable,stuck,inQueueSince,params]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get queue: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access build queue")
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, "build queue")
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	// Jenkins returns a structure with nested task information
@@ -980,6 +1115,7 @@ func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
 			ID   int `json:"id"`
 			Task struct {
 				Name string `json:"name"`
+				URL  string `json:"url"`
 			} `json:"task"`
 			Why          string `json:"why"`
 			Blocked      bool   `json:"blocked"`
@@ -991,7 +1127,7 @@ func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
 	}
 
 	if err := json.Unmarshal(body, &rawResult); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Transform raw result into QueueItem slice
@@ -999,7 +1135,7 @@ func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
 	for _, item := range rawResult.Items {
 		queueItem := QueueItem{
 			ID:           item.ID,
-			JobName:      item.Task.Name,
+			JobName:      queueTaskJobName(item.Task.Name, item.Task.URL),
 			Why:          item.Why,
 			Blocked:      item.Blocked,
 			Buildable:    item.Buildable,
@@ -1027,48 +1163,43 @@ func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
 	return queueItems, nil
 }
 
+// GetRunningBuilds lists every build currently running on any node, including
+// builds in folders and concurrent builds of the same job, with a single request
+// to the computer API
 func (c *Client) GetRunningBuilds(ctx context.Context) ([]RunningBuild, error) {
-	// Get the list of all jobs first
-	jobs, err := c.ListJobs(ctx, "")
+	path := "/computer/api/json?tree=computer[displayName,executors[" + runningExecutableTree +
+		"],oneOffExecutors[" + runningExecutableTree + "]]"
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list jobs: %w", err)
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	runningBuilds := []RunningBuild{}
-
-	// For each job, check if it has a running build
-	for _, job := range jobs {
-		// Get job details to check the last build
-		jobDetails, err := c.GetJob(ctx, job.Name)
-		if err != nil {
-			// Skip jobs we can't access
-			continue
-		}
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, "executors")
+	}
 
-		// Check if there's a last build
-		if jobDetails.LastBuild == nil {
-			continue
-		}
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
 
-		// Get the build details
-		build, err := c.GetBuild(ctx, job.Name, jobDetails.LastBuild.Number)
-		if err != nil {
-			// Skip builds we can't access
-			continue
-		}
+	var result struct {
+		Computer []rawComputer `json:"computer"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
 
-		// If the build is currently running, add it to the list
-		if build.Building {
-			runningBuild := RunningBuild{
-				JobName:           job.Name,
-				BuildNumber:       build.Number,
-				URL:               build.URL,
-				Timestamp:         build.Timestamp,
-				EstimatedDuration: build.EstimatedDuration,
-				Executor:          build.Executor,
-			}
-			runningBuilds = append(runningBuilds, runningBuild)
-		}
+	now := time.Now()
+	runningBuilds := []RunningBuild{}
+	seen := make(map[string]int)
+	for i := range result.Computer {
+		runningBuilds = result.Computer[i].runningBuilds(runningBuilds, seen, now)
 	}
 
 	return runningBuilds, nil
@@ -1077,7 +1208,7 @@ func (c *Client) GetRunningBuilds(ctx context.Context) ([]RunningBuild, error) {
 // GetQueueItem retrieves details about a specific queue item
 func (c *Client) GetQueueItem(ctx context.Context, queueID int) (*QueueItem, error) {
 	if queueID <= 0 {
-		return nil, fmt.Errorf("queue ID must be positive")
+		return nil, NewInvalidInputError("queue ID must be positive")
 	}
 
 	// Build the API path
@@ -1086,53 +1217,51 @@ func (c *Client) GetQueueItem(ctx context.Context, queueID int) (*QueueItem, err
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get queue item: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("queue item not found: %d", queueID)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access queue item %d", queueID)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("queue item %d", queueID))
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var rawResult struct {
 		ID   int `json:"id"`
 		Task struct {
 			Name string `json:"name"`
+			URL  string `json:"url"`
 		} `json:"task"`
-		Why          string `json:"why"`
-		Blocked      bool   `json:"blocked"`
-		Buildable    bool   `json:"buildable"`
-		Stuck        bool   `json:"stuck"`
-		InQueueSince int64  `json:"inQueueSince"`
-		Params       string `json:"params,omitempty"`
+		Why          string          `json:"why"`
+		Blocked      bool            `json:"blocked"`
+		Buildable    bool            `json:"buildable"`
+		Stuck        bool            `json:"stuck"`
+		InQueueSince int64           `json:"inQueueSince"`
+		Params       string          `json:"params,omitempty"`
+		Cancelled    bool            `json:"cancelled"`
+		Executable   *BuildReference `json:"executable"`
 	}
 
 	if err := json.Unmarshal(body, &rawResult); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	queueItem := &QueueItem{
 		ID:           rawResult.ID,
-		JobName:      rawResult.Task.Name,
+		JobName:      queueTaskJobName(rawResult.Task.Name, rawResult.Task.URL),
 		Why:          rawResult.Why,
 		Blocked:      rawResult.Blocked,
 		Buildable:    rawResult.Buildable,
 		Stuck:        rawResult.Stuck,
 		InQueueSince: rawResult.InQueueSince,
+		Cancelled:    rawResult.Cancelled,
+		Executable:   rawResult.Executable,
 	}
 
 	return queueItem, nil
@@ -1141,7 +1270,7 @@ func (c *Client) GetQueueItem(ctx context.Context, queueID int) (*QueueItem, err
 // CancelQueueItem cancels a queued build before it starts
 func (c *Client) CancelQueueItem(ctx context.Context, queueID int) error {
 	if queueID <= 0 {
-		return fmt.Errorf("queue ID must be positive")
+		return NewInvalidInputError("queue ID must be positive")
 	}
 
 	// Build the API path
@@ -1150,20 +1279,13 @@ func (c *Client) CancelQueueItem(ctx context.Context, queueID int) error {
 	// Make POST request
 	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return fmt.Errorf("failed to cancel queue item: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("queue item not found: %d", queueID)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("permission denied: insufficient permissions to cancel queue item %d", queueID)
-	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return NewHTTPError(resp, fmt.Sprintf("queue item %d", queueID))
 	}
 
 	return nil
@@ -1177,23 +1299,19 @@ func (c *Client) ListViews(ctx context.Context) ([]View, error) {
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list views: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to list views")
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, "views")
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var result struct {
@@ -1201,7 +1319,7 @@ func (c *Client) ListViews(ctx context.Context) ([]View, error) {
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	// Return empty list if no views (not an error)
@@ -1215,40 +1333,33 @@ func (c *Client) ListViews(ctx context.Context) ([]View, error) {
 // GetView retrieves details about a specific view
 func (c *Client) GetView(ctx context.Context, viewName string) (*ViewDetails, error) {
 	if viewName == "" {
-		return nil, fmt.Errorf("view name cannot be empty")
+		return nil, NewInvalidInputError("view name cannot be empty")
 	}
 
 	// Build the API path
-	path := fmt.Sprintf("/view/%s/api/json?tree=name,url,description,jobs[name,url,description,buildable,inQueue,color]", viewName)
+	path := fmt.Sprintf("/view/%s/api/json?tree=name,url,description,jobs[_class,name,fullName,url,description,buildable,inQueue,color]", url.PathEscape(viewName))
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get view: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("view not found: %s", viewName)
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to access view %s", viewName)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, fmt.Sprintf("view %s", viewName))
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var viewDetails ViewDetails
 	if err := json.Unmarshal(body, &viewDetails); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
 	return &viewDetails, nil
@@ -1257,7 +1368,7 @@ func (c *Client) GetView(ctx context.Context, viewName string) (*ViewDetails, er
 // CreateView creates a new view
 func (c *Client) CreateView(ctx context.Context, viewName string, viewType string) error {
 	if viewName == "" {
-		return fmt.Errorf("view name cannot be empty")
+		return NewInvalidInputError("view name cannot be empty")
 	}
 	if viewType == "" {
 		viewType = "hudson.model.ListView" // Default to list view
@@ -1290,119 +1401,78 @@ func (c *Client) CreateView(ctx context.Context, viewName string, viewType strin
 </%s>`, viewType, viewName, viewType)
 
 	// Make POST request with XML body
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader([]byte(viewConfig)))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add authentication
-	c.addAuthentication(req)
-
-	// Set headers for XML content
-	req.Header.Set("Content-Type", "application/xml")
-
-	// Add CSRF crumb for POST request
-	crumbField, crumb, err := c.getCrumb(ctx)
-	if err == nil && crumb != "" {
-		req.Header.Set(crumbField, crumb)
-	}
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doRequestWithContentType(ctx, http.MethodPost, path, strings.NewReader(viewConfig), "application/xml")
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
 	if resp.StatusCode == http.StatusConflict {
-		return fmt.Errorf("view already exists: %s", viewName)
-	}
-	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("permission denied: insufficient permissions to create view. Details: %s", string(body))
-	}
-	if resp.StatusCode == http.StatusBadRequest {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("bad request: invalid view configuration. Details: %s", string(body))
+		return NewInvalidInputError(fmt.Sprintf("view already exists: %s", viewName))
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusFound {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return NewHTTPError(resp, fmt.Sprintf("view %s", viewName))
 	}
 
 	return nil
 }
 
-type Node struct {
-	DisplayName        string `json:"displayName"`
-	Offline            bool   `json:"offline"`
-	TemporarilyOffline bool   `json:"temporarilyOffline"`
-	NumExecutors       int    `json:"numExecutors"`
-}
-
 // GetNodes retrieves all Jenkins nodes
 func (c *Client) GetNodes(ctx context.Context) ([]Node, error) {
-	// Build API path (customize fields as needed)
-	path := "/computer/api/json?tree=computer[displayName,offline,temporarilyOffline,numExecutors]"
+	// Build API path
+	path := "/computer/api/json?tree=computer[" + nodeTree + "]"
 
 	// Make GET request
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nodes: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Handle HTTP errors
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("permission denied: insufficient permissions to get nodes")
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, NewHTTPError(resp, "nodes")
 	}
 
 	// Parse response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
 	}
 
 	var result struct {
-		Computer []Node `json:"computer"`
+		Computer []rawComputer `json:"computer"`
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
 	}
 
-	// Return empty list if no nodes (not an error)
-	if result.Computer == nil {
-		return []Node{}, nil
+	now := time.Now()
+	nodes := make([]Node, 0, len(result.Computer))
+	for i := range result.Computer {
+		nodes = append(nodes, result.Computer[i].node(now))
 	}
 
-	return result.Computer, nil
+	return nodes, nil
 }
 func (c *Client) GetPipelineScript(ctx context.Context, job string) (string, error) {
-	path := fmt.Sprintf("/job/%s/config.xml", job)
+	path := jobPath(job) + "/config.xml"
 
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch config.xml: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("job not found: %s", job)
-	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		return "", NewHTTPError(resp, fmt.Sprintf("job %s", job))
 	}
 
 	configBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read config.xml: %w", err)
+		return "", WrapError(ErrorCodeJenkinsError, "failed to read config.xml", err)
 	}
 
 	xml := string(configBytes)
@@ -1416,18 +1486,25 @@ func (c *Client) GetPipelineScript(ctx context.Context, job string) (string, err
 		if len(match) >= 2 {
 			return match[1], nil
 		}
-		return "", fmt.Errorf("pipeline job found, but <script> block is empty or missing")
+		return "", NewInvalidInputError("pipeline job found, but <script> block is empty or missing")
 	}
 
 	// ────────────────────────────────────────────────
 	// Case 2: SCM Pipeline job (CpsScmFlowDefinition)
 	// ────────────────────────────────────────────────
 	if strings.Contains(xml, "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition") {
-		return "", fmt.Errorf("pipeline is defined in SCM (Git). Jenkins does not store the Jenkinsfile inline")
+		return "", NewInvalidInputError("pipeline is defined in SCM (Git). Jenkins does not store the Jenkinsfile inline")
+	}
+
+	// ────────────────────────────────────────────────
+	// Case 3: Multibranch project - the script lives in each branch
+	// ────────────────────────────────────────────────
+	if strings.Contains(xml, "WorkflowMultiBranchProject") {
+		return "", NewInvalidInputError(fmt.Sprintf("job '%s' is a multibranch project; list its branches and use a branch job such as '%s/main'", job, job))
 	}
 
 	// ────────────────────────────────────────────────
-	// Case 3: Multibranch / non-pipeline job
+	// Case 4: Non-pipeline job
 	// ────────────────────────────────────────────────
-	return "", fmt.Errorf("job '%s' is not an inline pipeline job (no <script> block available)", job)
+	return "", NewInvalidInputError(fmt.Sprintf("job '%s' is not an inline pipeline job (no <script> block available)", job))
 }
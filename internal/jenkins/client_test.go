@@ -2,10 +2,13 @@ package jenkins
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
 )
 
 func TestNewClient(t *testing.T) {
@@ -462,6 +465,239 @@ func TestGetArtifactValidation(t *testing.T) {
 	}
 }
 
+func TestJobPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobName string
+		want    string
+	}{
+		{
+			name:    "top-level job",
+			jobName: "my-job",
+			want:    "/job/my-job",
+		},
+		{
+			name:    "nested folders",
+			jobName: "team/service/main",
+			want:    "/job/team/job/service/job/main",
+		},
+		{
+			name:    "segment with spaces",
+			jobName: "team/my service",
+			want:    "/job/team/job/my%20service",
+		},
+		{
+			name:    "branch name with encoded slash",
+			jobName: "team/repo/feature%2Flogin",
+			want:    "/job/team/job/repo/job/feature%252Flogin",
+		},
+		{
+			name:    "leading and trailing slashes",
+			jobName: "/team/job-a/",
+			want:    "/job/team/job/job-a",
+		},
+		{
+			name:    "empty name",
+			jobName: "",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobPath(tt.jobName); got != tt.want {
+				t.Errorf("jobPath(%q) = %q, want %q", tt.jobName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobNameFromBuildURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildURL   string
+		wantJob    string
+		wantNumber int
+	}{
+		{
+			name:       "top-level job",
+			buildURL:   "https://jenkins.example.com/job/my-job/42/",
+			wantJob:    "my-job",
+			wantNumber: 42,
+		},
+		{
+			name:       "nested folders under a context path",
+			buildURL:   "https://example.com/jenkins/job/team/job/my%20service/job/main/7/",
+			wantJob:    "team/my service/main",
+			wantNumber: 7,
+		},
+		{
+			name:       "job URL without build number",
+			buildURL:   "https://jenkins.example.com/job/team/job/app/",
+			wantJob:    "team/app",
+			wantNumber: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, number := jobNameFromBuildURL(tt.buildURL)
+			if job != tt.wantJob || number != tt.wantNumber {
+				t.Errorf("jobNameFromBuildURL(%q) = (%q, %d), want (%q, %d)", tt.buildURL, job, number, tt.wantJob, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestGetRunningBuilds(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/computer/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"computer":[
+			{"displayName":"Built-In Node",
+			 "executors":[{"number":0,"progress":-1,"currentExecutable":null}],
+			 "oneOffExecutors":[
+				{"number":-1,"progress":50,"currentExecutable":{"number":7,"url":"http://jenkins/job/team/job/pipeline/7/","fullDisplayName":"team » pipeline #7","timestamp":1000,"estimatedDuration":60000}}]},
+			{"displayName":"agent-1",
+			 "executors":[
+				{"number":0,"progress":30,"currentExecutable":{"number":12,"url":"http://jenkins/job/app/12/","timestamp":1000,"estimatedDuration":60000}},
+				{"number":1,"progress":80,"currentExecutable":{"number":13,"url":"http://jenkins/job/app/13/","timestamp":1000,"estimatedDuration":60000}},
+				{"number":2,"progress":50,"currentExecutable":{"number":7,"url":"http://jenkins/job/team/job/pipeline/7/","timestamp":1000,"estimatedDuration":60000}}]}]}`))
+	}))
+
+	builds, err := client.GetRunningBuilds(context.Background())
+	if err != nil {
+		t.Fatalf("GetRunningBuilds() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("GetRunningBuilds() made %d requests, want 1", requests)
+	}
+
+	want := []struct {
+		job      string
+		number   int
+		node     string
+		executor string
+		progress int
+	}{
+		{"team/pipeline", 7, "agent-1", "#2", 50},
+		{"app", 12, "agent-1", "#0", 30},
+		{"app", 13, "agent-1", "#1", 80},
+	}
+	if len(builds) != len(want) {
+		t.Fatalf("GetRunningBuilds() returned %d builds, want %d: %+v", len(builds), len(want), builds)
+	}
+	for i, w := range want {
+		b := builds[i]
+		if b.JobName != w.job || b.BuildNumber != w.number || b.Node != w.node || b.Executor != w.executor || b.Progress != w.progress {
+			t.Errorf("builds[%d] = %+v, want %+v", i, b, w)
+		}
+		if b.ElapsedTime <= 0 {
+			t.Errorf("builds[%d].ElapsedTime = %d, want > 0", i, b.ElapsedTime)
+		}
+	}
+}
+
+func TestListJobsRecursive(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/json":
+			w.Write([]byte(`{"jobs":[
+				{"_class":"hudson.model.FreeStyleProject","name":"root-job","fullName":"root-job"},
+				{"_class":"com.cloudbees.hudson.plugins.folder.Folder","name":"team","fullName":"team"}]}`))
+		case "/job/team/api/json":
+			w.Write([]byte(`{"jobs":[
+				{"_class":"com.cloudbees.hudson.plugins.folder.Folder","name":"my service","fullName":"team/my service"}]}`))
+		case "/job/team/job/my service/api/json":
+			w.Write([]byte(`{"jobs":[
+				{"_class":"org.jenkinsci.plugins.workflow.job.WorkflowJob","name":"main"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	jobs, err := client.ListJobsRecursive(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListJobsRecursive() error = %v", err)
+	}
+
+	want := []string{"root-job", "team", "team/my service", "team/my service/main"}
+	if len(jobs) != len(want) {
+		t.Fatalf("ListJobsRecursive() returned %d jobs, want %d", len(jobs), len(want))
+	}
+	for i, job := range jobs {
+		if job.FullName != want[i] {
+			t.Errorf("jobs[%d].FullName = %q, want %q", i, job.FullName, want[i])
+		}
+	}
+}
+
+func TestTriggerBuildMasksPasswordParameters(t *testing.T) {
+	var query string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/deploy/api/json":
+			w.Write([]byte(`{"name":"deploy","property":[{"parameterDefinitions":[
+				{"name":"ENV","type":"StringParameterDefinition","defaultParameterValue":{"value":"dev"}},
+				{"name":"DB_PASS","type":"PasswordParameterDefinition","defaultParameterValue":{"value":"default-db-pass"}}]}]}`))
+		case "/job/deploy/buildWithParameters":
+			query = r.URL.RawQuery
+			w.Header().Set("Location", "http://jenkins/queue/item/31/")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	job, err := client.GetJob(context.Background(), "deploy")
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+	if job.Parameters[1].DefaultValue != redact.Mask {
+		t.Errorf("password parameter default = %v, want it masked", job.Parameters[1].DefaultValue)
+	}
+
+	queueItem, err := client.TriggerBuild(context.Background(), "deploy", map[string]string{"ENV": "prod", "DB_PASS": "trigger-db-pass"})
+	if err != nil {
+		t.Fatalf("TriggerBuild() error = %v", err)
+	}
+	if !contains(query, "trigger-db-pass") {
+		t.Errorf("Jenkins received query %q, want the real password", query)
+	}
+	if queueItem.Parameters["DB_PASS"] != redact.Mask || queueItem.Parameters["ENV"] != "prod" {
+		t.Errorf("queue item parameters = %v, want only the password masked", queueItem.Parameters)
+	}
+	if got := redact.String("connecting with trigger-db-pass"); contains(got, "trigger-db-pass") {
+		t.Errorf("password parameter was not registered as a secret: %q", got)
+	}
+}
+
+// newTestClient creates a Client that talks to an httptest server serving handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&config.Config{
+		JenkinsURL:   server.URL,
+		Username:     "admin",
+		Password:     "password",
+		Timeout:      5 * time.Second,
+		MaxRetries:   0,
+		RetryBackoff: 0,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	return client.(*Client)
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || 
@@ -0,0 +1,159 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Default limits for DiagnoseBuild
+const (
+	defaultDiagnoseTailLines    = 2000
+	defaultDiagnoseContextLines = 3
+	defaultDiagnoseMaxExcerpts  = 8
+	defaultDiagnoseMaxTests     = 5
+	maxExcerptLineLength        = 500
+)
+
+// errorMarkerPattern matches lines that commonly indicate the cause of a failure
+var errorMarkerPattern = regexp.MustCompile(
+	`\bERROR\b|\w*Exception\b|\bFAILED\b|\bFAILURE\b|` +
+		`(?i:exit (?:code|status|value))\W*[1-9]\d*|(?i:exited with (?:code|status))\W*[1-9]\d*`,
+)
+
+// DiagnoseBuild collects the build status, failing stage, failing tests and the
+// console log around error markers into a single compact summary.
+// Missing optional data (non-pipeline jobs, builds without test reports) is
+// recorded in Notes rather than failing the diagnosis.
+func (c *Client) DiagnoseBuild(ctx context.Context, jobName string, buildNumber int, opts DiagnoseOptions) (*BuildDiagnosis, error) {
+	if opts.TailLines <= 0 {
+		opts.TailLines = defaultDiagnoseTailLines
+	}
+	contextLines := defaultDiagnoseContextLines
+	if opts.ContextLines != nil {
+		if *opts.ContextLines < 0 {
+			return nil, NewInvalidInputError("context lines must be non-negative")
+		}
+		contextLines = *opts.ContextLines
+	}
+	if opts.MaxExcerpts <= 0 {
+		opts.MaxExcerpts = defaultDiagnoseMaxExcerpts
+	}
+	if opts.MaxTests <= 0 {
+		opts.MaxTests = defaultDiagnoseMaxTests
+	}
+
+	build, err := c.GetBuild(ctx, jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis := &BuildDiagnosis{
+		Build:       build,
+		LogExcerpts: []LogExcerpt{},
+	}
+	if build.Building {
+		diagnosis.Notes = append(diagnosis.Notes, "build is still running; results may be incomplete")
+	}
+
+	// Failing stage (pipeline jobs only)
+	if run, err := c.GetPipelineRun(ctx, jobName, buildNumber); err != nil {
+		diagnosis.Notes = append(diagnosis.Notes, fmt.Sprintf("stage data unavailable: %v", err))
+	} else {
+		diagnosis.FailedStage = run.FailedStage
+	}
+
+	// Failing tests
+	if report, err := c.GetTestReport(ctx, jobName, buildNumber); err != nil {
+		diagnosis.Notes = append(diagnosis.Notes, fmt.Sprintf("test report unavailable: %v", err))
+	} else {
+		diagnosis.Tests = report.Summarize(TestFilterFailed, opts.MaxTests)
+	}
+
+	// Log tail around error markers
+	chunk, err := c.GetBuildLogProgressive(ctx, jobName, buildNumber, 0, opts.TailLines)
+	if err != nil {
+		diagnosis.Notes = append(diagnosis.Notes, fmt.Sprintf("console log unavailable: %v", err))
+		return diagnosis, nil
+	}
+
+	if chunk.StartLine == 0 {
+		// Only the end of a long log was read, so the position of the scanned lines
+		// within the whole log is unknown; number them from the first scanned line
+		diagnosis.LogBytes = chunk.NextStart
+		diagnosis.LinesFromTail = true
+		diagnosis.LogExcerpts = findErrorExcerpts(chunk.Text, 1, contextLines, opts.MaxExcerpts)
+		diagnosis.Notes = append(diagnosis.Notes,
+			fmt.Sprintf("the log is %d bytes long; only its last %d lines were scanned and excerpt line numbers count from the first of them",
+				chunk.NextStart, chunk.Lines))
+		return diagnosis, nil
+	}
+
+	diagnosis.LogLines = chunk.StartLine + chunk.Lines - 1
+	diagnosis.LogExcerpts = findErrorExcerpts(chunk.Text, chunk.StartLine, contextLines, opts.MaxExcerpts)
+	if chunk.Truncated {
+		diagnosis.Notes = append(diagnosis.Notes,
+			fmt.Sprintf("only the last %d of %d log lines were scanned", chunk.Lines, diagnosis.LogLines))
+	}
+
+	return diagnosis, nil
+}
+
+// findErrorExcerpts returns windows of log lines around error markers.
+// firstLine is the line number of the first line in text. Overlapping windows are
+// merged and, when there are more than maxExcerpts, the last ones are kept since
+// the cause of a failure is usually close to the end of the log.
+func findErrorExcerpts(text string, firstLine, contextLines, maxExcerpts int) []LogExcerpt {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	excerpts := []LogExcerpt{}
+	lastEnd := -1
+	for i, line := range lines {
+		marker := errorMarkerPattern.FindString(line)
+		if marker == "" {
+			continue
+		}
+
+		start := max(i-contextLines, 0)
+		end := min(i+contextLines, len(lines)-1)
+
+		// Merge with the previous excerpt when the windows overlap
+		if len(excerpts) > 0 && start <= lastEnd+1 {
+			prev := &excerpts[len(excerpts)-1]
+			prev.EndLine = firstLine + end
+			prev.Text = joinExcerptLines(lines[prev.StartLine-firstLine : end+1])
+			lastEnd = end
+			continue
+		}
+
+		excerpts = append(excerpts, LogExcerpt{
+			StartLine: firstLine + start,
+			EndLine:   firstLine + end,
+			Marker:    marker,
+			Text:      joinExcerptLines(lines[start : end+1]),
+		})
+		lastEnd = end
+	}
+
+	if len(excerpts) > maxExcerpts {
+		excerpts = excerpts[len(excerpts)-maxExcerpts:]
+	}
+
+	return excerpts
+}
+
+// joinExcerptLines joins log lines, shortening very long ones
+func joinExcerptLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if len(line) > maxExcerptLineLength {
+			line = line[:maxExcerptLineLength] + "..."
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFindErrorExcerpts(t *testing.T) {
+	lines := []string{
+		"Started by user admin",       // 10
+		"Building in workspace",       // 11
+		"[INFO] compiling",            // 12
+		"[ERROR] cannot find symbol",  // 13
+		"[INFO] BUILD FAILURE",        // 14
+		"cleanup",                     // 15
+		"cleanup",                     // 16
+		"cleanup",                     // 17
+		"cleanup",                     // 18
+		"cleanup",                     // 19
+		"script returned exit code 2", // 20
+		"Finished: FAILURE",           // 21
+	}
+
+	excerpts := findErrorExcerpts(strings.Join(lines, "\n"), 10, 1, 10)
+	if len(excerpts) != 2 {
+		t.Fatalf("findErrorExcerpts() returned %d excerpts, want 2: %+v", len(excerpts), excerpts)
+	}
+
+	first := excerpts[0]
+	if first.StartLine != 12 || first.EndLine != 15 || first.Marker != "ERROR" {
+		t.Errorf("first excerpt = lines %d-%d marker %q, want 12-15 ERROR", first.StartLine, first.EndLine, first.Marker)
+	}
+	if !strings.Contains(first.Text, "BUILD FAILURE") {
+		t.Errorf("first excerpt should merge the adjacent BUILD FAILURE line: %q", first.Text)
+	}
+
+	last := excerpts[1]
+	if last.StartLine != 19 || last.EndLine != 21 || last.Marker != "exit code 2" {
+		t.Errorf("last excerpt = lines %d-%d marker %q, want 19-21 \"exit code 2\"", last.StartLine, last.EndLine, last.Marker)
+	}
+
+	limited := findErrorExcerpts(strings.Join(lines, "\n"), 10, 1, 1)
+	if len(limited) != 1 || limited[0].StartLine != 19 {
+		t.Errorf("findErrorExcerpts(max=1) should keep the last excerpt, got %+v", limited)
+	}
+}
+
+func TestDiagnoseBuild(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/8/api/json":
+			w.Write([]byte(`{"number": 8, "result": "FAILURE", "building": false}`))
+		case "/job/app/8/wfapi/describe":
+			w.Write([]byte(`{"stages": [{"id": "5", "name": "Test", "status": "FAILED"}]}`))
+		case "/job/app/8/logText/progressiveText":
+			w.Header().Set("X-Text-Size", "64")
+			w.Write([]byte("step one\nstep two\njava.lang.IllegalStateException: boom\nFinished: FAILURE\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	diagnosis, err := client.DiagnoseBuild(context.Background(), "app", 8, DiagnoseOptions{})
+	if err != nil {
+		t.Fatalf("DiagnoseBuild() error = %v", err)
+	}
+	if diagnosis.Build.Result != "FAILURE" {
+		t.Errorf("Build.Result = %s, want FAILURE", diagnosis.Build.Result)
+	}
+	if diagnosis.FailedStage == nil || diagnosis.FailedStage.Name != "Test" {
+		t.Errorf("FailedStage = %+v, want Test", diagnosis.FailedStage)
+	}
+	if diagnosis.Tests != nil {
+		t.Errorf("Tests = %+v, want nil when no test report is published", diagnosis.Tests)
+	}
+	if len(diagnosis.Notes) != 1 || !strings.Contains(diagnosis.Notes[0], "test report unavailable") {
+		t.Errorf("Notes = %v, want a test report note", diagnosis.Notes)
+	}
+	if diagnosis.LogLines != 4 || len(diagnosis.LogExcerpts) != 1 {
+		t.Fatalf("LogLines = %d, excerpts = %d, want 4, 1", diagnosis.LogLines, len(diagnosis.LogExcerpts))
+	}
+	if marker := diagnosis.LogExcerpts[0].Marker; marker != "IllegalStateException" {
+		t.Errorf("excerpt marker = %q, want IllegalStateException", marker)
+	}
+}
+
+func TestDiagnoseBuildContextLines(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/8/api/json":
+			w.Write([]byte(`{"number": 8, "result": "FAILURE", "building": false}`))
+		case "/job/app/8/logText/progressiveText":
+			w.Write([]byte("step one\nstep two\n[ERROR] boom\nstep three\nstep four\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	tests := []struct {
+		name         string
+		contextLines *int
+		wantText     string
+		wantErr      bool
+	}{
+		{name: "default", wantText: "step one\nstep two\n[ERROR] boom\nstep three\nstep four"},
+		{name: "one line", contextLines: intPtr(1), wantText: "step two\n[ERROR] boom\nstep three"},
+		{name: "marker only", contextLines: intPtr(0), wantText: "[ERROR] boom"},
+		{name: "negative", contextLines: intPtr(-1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnosis, err := client.DiagnoseBuild(context.Background(), "app", 8, DiagnoseOptions{ContextLines: tt.contextLines})
+			if tt.wantErr {
+				if !IsErrorCode(err, ErrorCodeInvalidInput) {
+					t.Errorf("DiagnoseBuild() error = %v, want %s", err, ErrorCodeInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DiagnoseBuild() error = %v", err)
+			}
+			if len(diagnosis.LogExcerpts) != 1 || diagnosis.LogExcerpts[0].Text != tt.wantText {
+				t.Errorf("excerpts = %+v, want one excerpt %q", diagnosis.LogExcerpts, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestDiagnoseBuildLargeLog(t *testing.T) {
+	var b strings.Builder
+	for i := 1; b.Len() <= 3*maxLogChunkBytes/2; i++ {
+		fmt.Fprintf(&b, "line %07d\n", i)
+	}
+	b.WriteString("[ERROR] boom\nFinished: FAILURE\n")
+	fullLog := b.String()
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/8/api/json":
+			w.Write([]byte(`{"number": 8, "result": "FAILURE", "building": false}`))
+		case "/job/app/8/logText/progressiveText":
+			start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+			w.Header().Set("X-Text-Size", strconv.Itoa(len(fullLog)))
+			w.Write([]byte(fullLog[start:]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	diagnosis, err := client.DiagnoseBuild(context.Background(), "app", 8, DiagnoseOptions{TailLines: 10, ContextLines: intPtr(1)})
+	if err != nil {
+		t.Fatalf("DiagnoseBuild() error = %v", err)
+	}
+	if diagnosis.LogLines != 0 || diagnosis.LogBytes != int64(len(fullLog)) || !diagnosis.LinesFromTail {
+		t.Errorf("LogLines = %d, LogBytes = %d, LinesFromTail = %v, want 0, %d, true",
+			diagnosis.LogLines, diagnosis.LogBytes, diagnosis.LinesFromTail, len(fullLog))
+	}
+	if len(diagnosis.LogExcerpts) != 1 {
+		t.Fatalf("excerpts = %+v, want one", diagnosis.LogExcerpts)
+	}
+	if excerpt := diagnosis.LogExcerpts[0]; excerpt.StartLine != 8 || excerpt.EndLine != 10 {
+		t.Errorf("excerpt lines %d-%d, want 8-10 counted from the first scanned line", excerpt.StartLine, excerpt.EndLine)
+	}
+	var note string
+	for _, n := range diagnosis.Notes {
+		if strings.Contains(n, "lines were scanned") {
+			note = n
+		}
+	}
+	if want := fmt.Sprintf("the log is %d bytes long; only its last 10 lines", len(fullLog)); !strings.HasPrefix(note, want) {
+		t.Errorf("Notes = %v, want a note starting %q", diagnosis.Notes, want)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -1,8 +1,14 @@
 package jenkins
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
 )
 
 // ErrorCode represents standardized error codes for Jenkins operations
@@ -39,6 +45,9 @@ type ErrorResponse struct {
 	Code    ErrorCode              `json:"code"`
 	Message string                 `json:"message"`
 	Details map[string]interface{} `json:"details,omitempty"`
+
+	// cause is the underlying error, if any, exposed through Unwrap
+	cause error
 }
 
 // Error implements the error interface for ErrorResponse
@@ -49,6 +58,11 @@ func (e *ErrorResponse) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// Unwrap returns the underlying error wrapped by WrapError or NewRequestError
+func (e *ErrorResponse) Unwrap() error {
+	return e.cause
+}
+
 // NewError creates a new ErrorResponse with the given code and message
 func NewError(code ErrorCode, message string) *ErrorResponse {
 	return &ErrorResponse{
@@ -81,6 +95,7 @@ func WrapError(code ErrorCode, message string, err error) error {
 		Code:    code,
 		Message: message,
 		Details: details,
+		cause:   err,
 	}
 }
 
@@ -143,3 +158,88 @@ func GetErrorCode(err error) (ErrorCode, bool) {
 	}
 	return "", false
 }
+
+// maxErrorBodyLength bounds how much of a Jenkins error page is kept in error details
+const maxErrorBodyLength = 512
+
+// NewHTTPError maps an unsuccessful Jenkins HTTP response to an ErrorResponse.
+// The status code, request URL and the start of the response body are recorded in Details.
+func NewHTTPError(resp *http.Response, resource string) *ErrorResponse {
+	code := statusErrorCode(resp.StatusCode)
+
+	var message string
+	switch code {
+	case ErrorCodeAuthFailed:
+		message = fmt.Sprintf("authentication failed accessing %s", resource)
+	case ErrorCodePermissionDenied:
+		message = fmt.Sprintf("permission denied: %s", resource)
+	case ErrorCodeNotFound:
+		message = fmt.Sprintf("%s not found", resource)
+	case ErrorCodeInvalidInput:
+		message = fmt.Sprintf("invalid request for %s", resource)
+	case ErrorCodeTimeout:
+		message = fmt.Sprintf("operation timed out: %s", resource)
+	default:
+		message = fmt.Sprintf("unexpected status code %d for %s", resp.StatusCode, resource)
+	}
+
+	details := map[string]interface{}{
+		"status_code": resp.StatusCode,
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		details["url"] = redact.String(resp.Request.URL.Redacted())
+	}
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength)); err == nil && len(body) > 0 {
+		details["body"] = redact.String(string(body))
+	}
+
+	return NewErrorWithDetails(code, message, details)
+}
+
+// statusErrorCode maps an unsuccessful Jenkins HTTP status code to an ErrorCode
+func statusErrorCode(status int) ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrorCodeAuthFailed
+	case http.StatusForbidden:
+		return ErrorCodePermissionDenied
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusBadRequest, http.StatusConflict:
+		return ErrorCodeInvalidInput
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrorCodeTimeout
+	default:
+		return ErrorCodeJenkinsError
+	}
+}
+
+// NewRequestError maps a failure to send a Jenkins request (no HTTP response) to an ErrorResponse.
+// Deadlines and network timeouts become TIMEOUT; everything else is a NETWORK_ERROR.
+func NewRequestError(req *http.Request, err error) *ErrorResponse {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp
+	}
+
+	code, message := ErrorCodeNetworkError, "failed to reach Jenkins"
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		code, message = ErrorCodeTimeout, "operation timed out: request to Jenkins"
+	}
+
+	details := map[string]interface{}{
+		"underlying_error": err.Error(),
+	}
+	if req != nil && req.URL != nil {
+		details["method"] = req.Method
+		details["url"] = redact.String(req.URL.Redacted())
+	}
+
+	return &ErrorResponse{
+		Code:    code,
+		Message: message,
+		Details: details,
+		cause:   err,
+	}
+}
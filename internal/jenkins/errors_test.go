@@ -0,0 +1,117 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+func TestClientErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantCode ErrorCode
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, wantCode: ErrorCodeAuthFailed},
+		{name: "forbidden", status: http.StatusForbidden, wantCode: ErrorCodePermissionDenied},
+		{name: "not found", status: http.StatusNotFound, wantCode: ErrorCodeNotFound},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, wantCode: ErrorCodeTimeout},
+		{name: "server error", status: http.StatusInternalServerError, wantCode: ErrorCodeJenkinsError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nope", tt.status)
+			}))
+
+			_, err := client.GetJob(context.Background(), "team/app")
+
+			var errResp *ErrorResponse
+			if !errors.As(err, &errResp) {
+				t.Fatalf("GetJob() error = %v, want *ErrorResponse", err)
+			}
+			if errResp.Code != tt.wantCode {
+				t.Errorf("Code = %s, want %s", errResp.Code, tt.wantCode)
+			}
+			if errResp.Details["status_code"] != tt.status {
+				t.Errorf("Details[status_code] = %v, want %d", errResp.Details["status_code"], tt.status)
+			}
+			if url, _ := errResp.Details["url"].(string); !strings.Contains(url, "/job/team/job/app/api/json") {
+				t.Errorf("Details[url] = %q, want job API URL", url)
+			}
+		})
+	}
+}
+
+func TestClientErrorBodyRedacted(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "script failed: password=hunter2", http.StatusInternalServerError)
+	}))
+
+	_, err := client.GetJob(context.Background(), "app")
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("GetJob() error = %v, want *ErrorResponse", err)
+	}
+	body, _ := errResp.Details["body"].(string)
+	if !strings.Contains(body, "script failed") || strings.Contains(body, "hunter2") {
+		t.Errorf("Details[body] = %q, want the body with the password masked", body)
+	}
+}
+
+func TestClientValidationErrorCode(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+
+	_, err := client.GetBuild(context.Background(), "", 1)
+	if !IsErrorCode(err, ErrorCodeInvalidInput) {
+		t.Errorf("GetBuild() error = %v, want %s", err, ErrorCodeInvalidInput)
+	}
+}
+
+func TestClientRequestErrorCodes(t *testing.T) {
+	t.Run("network error", func(t *testing.T) {
+		client, err := NewClient(&config.Config{
+			JenkinsURL: "http://127.0.0.1:1",
+			Username:   "admin",
+			Password:   "password",
+			Timeout:    5 * time.Second,
+		})
+		if err != nil {
+			t.Fatalf("NewClient() failed: %v", err)
+		}
+
+		_, err = client.GetQueue(context.Background())
+		if !IsErrorCode(err, ErrorCodeNetworkError) {
+			t.Fatalf("GetQueue() error = %v, want %s", err, ErrorCodeNetworkError)
+		}
+		var errResp *ErrorResponse
+		errors.As(err, &errResp)
+		if errResp.Details["method"] != http.MethodGet || errResp.Details["url"] == nil {
+			t.Errorf("Details = %v, want method and url", errResp.Details)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetQueue(ctx)
+		if !IsErrorCode(err, ErrorCodeTimeout) {
+			t.Fatalf("GetQueue() error = %v, want %s", err, ErrorCodeTimeout)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetQueue() error = %v, want it to wrap context.DeadlineExceeded", err)
+		}
+	})
+}
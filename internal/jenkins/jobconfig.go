@@ -0,0 +1,195 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetJobConfig retrieves the raw config.xml of a job
+func (c *Client) GetJobConfig(ctx context.Context, jobName string) (string, error) {
+	if jobName == "" {
+		return "", NewInvalidInputError("job name cannot be empty")
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, jobPath(jobName)+"/config.xml", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	configBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", WrapError(ErrorCodeJenkinsError, "failed to read config.xml", err)
+	}
+
+	return string(configBytes), nil
+}
+
+// CreateJob creates a new job from a config.xml document.
+// The job name may include parent folders, which must already exist.
+func (c *Client) CreateJob(ctx context.Context, jobName string, configXML string) error {
+	if err := validateConfigXML(configXML); err != nil {
+		return err
+	}
+
+	parent, name, err := splitJobName(jobName)
+	if err != nil {
+		return err
+	}
+
+	path := jobPath(parent) + "/createItem?name=" + url.QueryEscape(name)
+	return c.postJobAction(ctx, path, strings.NewReader(configXML), "application/xml", fmt.Sprintf("job %s", jobName))
+}
+
+// CopyJob creates a new job as a copy of an existing one.
+// The new job is created in the folder given by its name; the copy starts disabled
+// until it is saved or enabled, as in the Jenkins UI.
+func (c *Client) CopyJob(ctx context.Context, fromJob string, toJob string) error {
+	if fromJob == "" {
+		return NewInvalidInputError("source job name cannot be empty")
+	}
+
+	parent, name, err := splitJobName(toJob)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("mode", "copy")
+	// An absolute item path lets the source live in a different folder
+	query.Set("from", "/"+strings.Trim(fromJob, "/"))
+
+	path := jobPath(parent) + "/createItem?" + query.Encode()
+	return c.postJobAction(ctx, path, nil, "", fmt.Sprintf("job %s", fromJob))
+}
+
+// UpdateJobConfig replaces the config.xml of an existing job
+func (c *Client) UpdateJobConfig(ctx context.Context, jobName string, configXML string) error {
+	if jobName == "" {
+		return NewInvalidInputError("job name cannot be empty")
+	}
+	if err := validateConfigXML(configXML); err != nil {
+		return err
+	}
+
+	path := jobPath(jobName) + "/config.xml"
+	return c.postJobAction(ctx, path, strings.NewReader(configXML), "application/xml", fmt.Sprintf("job %s", jobName))
+}
+
+// SetJobEnabled enables or disables a job
+func (c *Client) SetJobEnabled(ctx context.Context, jobName string, enabled bool) error {
+	if jobName == "" {
+		return NewInvalidInputError("job name cannot be empty")
+	}
+
+	action := "/disable"
+	if enabled {
+		action = "/enable"
+	}
+
+	return c.postJobAction(ctx, jobPath(jobName)+action, nil, "", fmt.Sprintf("job %s", jobName))
+}
+
+// RenameJob renames a job within its current folder
+func (c *Client) RenameJob(ctx context.Context, jobName string, newName string) error {
+	if jobName == "" {
+		return NewInvalidInputError("job name cannot be empty")
+	}
+	if newName == "" || strings.Contains(newName, "/") {
+		return NewInvalidInputError("new name must be a non-empty name without '/'; use copy and delete to move a job between folders")
+	}
+
+	path := jobPath(jobName) + "/confirmRename?newName=" + url.QueryEscape(newName)
+	return c.postJobAction(ctx, path, nil, "", fmt.Sprintf("job %s", jobName))
+}
+
+// DeleteJob permanently deletes a job and its build history
+func (c *Client) DeleteJob(ctx context.Context, jobName string) error {
+	if jobName == "" {
+		return NewInvalidInputError("job name cannot be empty")
+	}
+
+	return c.postJobAction(ctx, jobPath(jobName)+"/doDelete", nil, "", fmt.Sprintf("job %s", jobName))
+}
+
+// postJobAction sends a POST request for a job lifecycle action and maps failures to ErrorResponse values.
+// Jenkins answers most of these actions with a redirect, so any 2xx or 3xx status is a success.
+func (c *Client) postJobAction(ctx context.Context, path string, body io.Reader, contentType string, resource string) error {
+	var resp *http.Response
+	var err error
+	if contentType != "" {
+		resp, err = c.doRequestWithContentType(ctx, http.MethodPost, path, body, contentType)
+	} else {
+		resp, err = c.doRequest(ctx, http.MethodPost, path, body)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		return nil
+	}
+
+	// Jenkins reports an existing item on createItem as 400 with an explanatory header
+	if reason := resp.Header.Get("X-Error"); reason != "" {
+		httpErr := NewHTTPError(resp, resource)
+		httpErr.Message = reason
+		return httpErr
+	}
+
+	return NewHTTPError(resp, resource)
+}
+
+// splitJobName splits a full job name into its parent folder and leaf name
+func splitJobName(jobName string) (string, string, error) {
+	jobName = strings.Trim(jobName, "/")
+	if jobName == "" {
+		return "", "", NewInvalidInputError("job name cannot be empty")
+	}
+
+	idx := strings.LastIndex(jobName, "/")
+	if idx == -1 {
+		return "", jobName, nil
+	}
+	return jobName[:idx], jobName[idx+1:], nil
+}
+
+// validateConfigXML checks that a job configuration is a well-formed XML document
+func validateConfigXML(configXML string) error {
+	if strings.TrimSpace(configXML) == "" {
+		return NewInvalidInputError("config XML cannot be empty")
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(xmlDeclPattern.ReplaceAll([]byte(configXML), nil)))
+	hasRoot := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return NewInvalidInputError(fmt.Sprintf("config XML is not well-formed: %v", err))
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			hasRoot = true
+		}
+	}
+	if !hasRoot {
+		return NewInvalidInputError("config XML has no root element")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package jenkins
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestJobConfigLifecycle(t *testing.T) {
+	type call struct {
+		method, path, query, contentType, body string
+	}
+	var calls []call
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/crumbIssuer/api/json" {
+			w.Write([]byte(`{"crumb":"abc","crumbRequestField":"Jenkins-Crumb"}`))
+			return
+		}
+		if r.Method == http.MethodPost && r.Header.Get("Jenkins-Crumb") != "abc" {
+			t.Errorf("%s %s sent without crumb", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Content-Type"), string(body)})
+
+		switch r.URL.Path {
+		case "/job/team/job/app/config.xml":
+			if r.Method == http.MethodGet {
+				w.Write([]byte("<project/>"))
+			}
+		case "/job/team/job/missing/doDelete":
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+	configXML := "<?xml version='1.1' encoding='UTF-8'?><project><description>x</description></project>"
+
+	got, err := client.GetJobConfig(ctx, "team/app")
+	if err != nil || got != "<project/>" {
+		t.Fatalf("GetJobConfig() = %q, %v", got, err)
+	}
+	if err := client.CreateJob(ctx, "team/new app", configXML); err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+	if err := client.CopyJob(ctx, "team/app", "other/app-copy"); err != nil {
+		t.Fatalf("CopyJob() error = %v", err)
+	}
+	if err := client.UpdateJobConfig(ctx, "team/app", configXML); err != nil {
+		t.Fatalf("UpdateJobConfig() error = %v", err)
+	}
+	if err := client.SetJobEnabled(ctx, "team/app", false); err != nil {
+		t.Fatalf("SetJobEnabled() error = %v", err)
+	}
+	if err := client.RenameJob(ctx, "team/app", "app2"); err != nil {
+		t.Fatalf("RenameJob() error = %v", err)
+	}
+
+	want := []call{
+		{http.MethodGet, "/job/team/job/app/config.xml", "", "", ""},
+		{http.MethodPost, "/job/team/createItem", "name=new+app", "application/xml", configXML},
+		{http.MethodPost, "/job/other/createItem", "from=%2Fteam%2Fapp&mode=copy&name=app-copy", "", ""},
+		{http.MethodPost, "/job/team/job/app/config.xml", "", "application/xml", configXML},
+		{http.MethodPost, "/job/team/job/app/disable", "", "", ""},
+		{http.MethodPost, "/job/team/job/app/confirmRename", "newName=app2", "", ""},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("got %d requests, want %d: %+v", len(calls), len(want), calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("request %d = %+v, want %+v", i, calls[i], want[i])
+		}
+	}
+
+	err = client.DeleteJob(ctx, "team/missing")
+	if !IsErrorCode(err, ErrorCodeNotFound) {
+		t.Errorf("DeleteJob() error = %v, want %s", err, ErrorCodeNotFound)
+	}
+}
+
+func TestJobConfigRequestErrorCodes(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/crumbIssuer/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetJobConfig(ctx, "app"); !IsErrorCode(err, ErrorCodeTimeout) {
+		t.Errorf("GetJobConfig() error = %v, want %s", err, ErrorCodeTimeout)
+	}
+	if err := client.RenameJob(ctx, "app", "app2"); !IsErrorCode(err, ErrorCodeTimeout) {
+		t.Errorf("RenameJob() error = %v, want %s", err, ErrorCodeTimeout)
+	}
+}
+
+func TestValidateConfigXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		wantErr bool
+	}{
+		{name: "valid with 1.1 declaration", xml: "<?xml version='1.1' encoding='UTF-8'?>\n<project/>", wantErr: false},
+		{name: "empty", xml: "  ", wantErr: true},
+		{name: "malformed", xml: "<project><builders></project>", wantErr: true},
+		{name: "no root element", xml: "just text", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfigXML(tt.xml)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfigXML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsErrorCode(err, ErrorCodeInvalidInput) {
+				t.Errorf("validateConfigXML() error code = %v, want %s", err, ErrorCodeInvalidInput)
+			}
+		})
+	}
+}
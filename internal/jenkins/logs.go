@@ -0,0 +1,193 @@
+package jenkins
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxLogChunkBytes caps the log text GetBuildLogProgressive reads in one call
+const maxLogChunkBytes = 1 << 20
+
+// GetBuildLogProgressive reads the console log of a build from the given byte offset
+// using Jenkins' logText/progressiveText API.
+// At most maxLogChunkBytes of text are returned; a longer range is cut after its last
+// complete line and reported with MoreData so the rest can be read with NextStart.
+// If tailLines is greater than zero only the last tailLines lines of the range are
+// returned; the log is streamed through a bounded buffer so memory stays proportional
+// to tailLines rather than to the log size, and a range longer than maxLogChunkBytes
+// is read from that many bytes before its end.
+// The returned NextStart should be passed as start on the next call to follow a
+// running build; offsets refer to the raw log file and cannot be derived from the
+// length of the returned text.
+func (c *Client) GetBuildLogProgressive(ctx context.Context, jobName string, buildNumber int, start int64, tailLines int) (*LogChunk, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+	if start < 0 {
+		return nil, NewInvalidInputError("start offset must be non-negative")
+	}
+	if tailLines < 0 {
+		return nil, NewInvalidInputError("tail lines must be non-negative")
+	}
+
+	body, chunk, err := c.openProgressiveLog(ctx, jobName, buildNumber, start)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { body.Close() }()
+
+	if tailLines == 0 {
+		logBytes, err := io.ReadAll(io.LimitReader(body, maxLogChunkBytes+1))
+		if err != nil {
+			return nil, WrapError(ErrorCodeJenkinsError, "failed to read log content", err)
+		}
+		if len(logBytes) > maxLogChunkBytes {
+			logBytes = logBytes[:maxLogChunkBytes]
+			if i := bytes.LastIndexByte(logBytes, '\n'); i >= 0 {
+				logBytes = logBytes[:i+1]
+			}
+			// Jenkins cannot end a read at an offset, so the rest is read from the
+			// end of the returned text. Console annotations Jenkins strips from the
+			// text may make the next chunk repeat a little of this one.
+			chunk.NextStart = chunk.Start + int64(len(logBytes))
+			chunk.MoreData = true
+		}
+		chunk.Text = string(logBytes)
+		chunk.Lines = countLines(chunk.Text)
+		return chunk, nil
+	}
+
+	// Only the end of a long range is read. Line numbers are unknown then, and
+	// the first line read may be partial, so it is skipped.
+	var reader io.Reader = body
+	fromEnd := chunk.NextStart-chunk.Start > maxLogChunkBytes
+	if fromEnd {
+		rangeStart := chunk.Start
+		body.Close()
+		body, chunk, err = c.openProgressiveLog(ctx, jobName, buildNumber, chunk.NextStart-maxLogChunkBytes)
+		if err != nil {
+			return nil, err
+		}
+		chunk.Start = rangeStart
+
+		buffered := bufio.NewReader(body)
+		if _, err := buffered.ReadString('\n'); err != nil && !errors.Is(err, io.EOF) {
+			return nil, WrapError(ErrorCodeJenkinsError, "failed to read log content", err)
+		}
+		reader = buffered
+	}
+
+	lines, total, err := tailReader(reader, tailLines)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read log content", err)
+	}
+
+	chunk.Text = strings.Join(lines, "")
+	chunk.Lines = len(lines)
+	chunk.StartLine = total - len(lines) + 1
+	chunk.Truncated = total > len(lines)
+	if fromEnd {
+		chunk.StartLine = 0
+		chunk.Truncated = true
+	}
+
+	return chunk, nil
+}
+
+// openProgressiveLog requests a build's console log from the given byte offset
+// and returns the response body along with a chunk describing the range
+func (c *Client) openProgressiveLog(ctx context.Context, jobName string, buildNumber int, start int64) (io.ReadCloser, *LogChunk, error) {
+	// Build the API path for progressive console text
+	path := fmt.Sprintf("%s/%d/logText/progressiveText?start=%d", jobPath(jobName), buildNumber, start)
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, nil, NewHTTPError(resp, fmt.Sprintf("build log %s #%d", jobName, buildNumber))
+	}
+
+	chunk := &LogChunk{
+		Start:     start,
+		NextStart: start,
+		MoreData:  strings.EqualFold(resp.Header.Get("X-More-Data"), "true"),
+		StartLine: 1,
+	}
+
+	// X-Text-Size is the offset just past the returned text
+	if size := resp.Header.Get("X-Text-Size"); size != "" {
+		nextStart, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			resp.Body.Close()
+			return nil, nil, WrapError(ErrorCodeJenkinsError, fmt.Sprintf("invalid X-Text-Size header %q", size), err)
+		}
+		// Jenkins restarts from zero when start is past the end of the log
+		if nextStart < start {
+			chunk.Start = 0
+		}
+		chunk.NextStart = nextStart
+	}
+
+	return resp.Body, chunk, nil
+}
+
+// tailReader reads r to the end and returns its last n lines (including line
+// terminators) along with the total number of lines read
+func tailReader(r io.Reader, n int) ([]string, int, error) {
+	ring := make([]string, n)
+	total := 0
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			ring[total%n] = line
+			total++
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	count := total
+	if count > n {
+		count = n
+	}
+
+	lines := make([]string, 0, count)
+	for i := total - count; i < total; i++ {
+		lines = append(lines, ring[i%n])
+	}
+
+	return lines, total, nil
+}
+
+// countLines counts the lines in s, including a final unterminated line
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,171 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetBuildLogProgressive(t *testing.T) {
+	const fullLog = "line 1\nline 2\nline 3\nline 4\nline 5"
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/team/job/app/3/logText/progressiveText" {
+			http.NotFound(w, r)
+			return
+		}
+		start := r.URL.Query().Get("start")
+		w.Header().Set("X-Text-Size", "120")
+		w.Header().Set("X-More-Data", "true")
+		if start == "100" {
+			w.Write([]byte("line 5"))
+			return
+		}
+		w.Write([]byte(fullLog))
+	}))
+
+	tests := []struct {
+		name          string
+		start         int64
+		tailLines     int
+		wantText      string
+		wantStartLine int
+		wantLines     int
+		wantTruncated bool
+	}{
+		{
+			name:          "full log from start",
+			start:         0,
+			wantText:      fullLog,
+			wantStartLine: 1,
+			wantLines:     5,
+		},
+		{
+			name:          "continue from offset",
+			start:         100,
+			wantText:      "line 5",
+			wantStartLine: 1,
+			wantLines:     1,
+		},
+		{
+			name:          "tail last two lines",
+			start:         0,
+			tailLines:     2,
+			wantText:      "line 4\nline 5",
+			wantStartLine: 4,
+			wantLines:     2,
+			wantTruncated: true,
+		},
+		{
+			name:          "tail larger than log",
+			start:         0,
+			tailLines:     10,
+			wantText:      fullLog,
+			wantStartLine: 1,
+			wantLines:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk, err := client.GetBuildLogProgressive(context.Background(), "team/app", 3, tt.start, tt.tailLines)
+			if err != nil {
+				t.Fatalf("GetBuildLogProgressive() error = %v", err)
+			}
+			if chunk.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", chunk.Text, tt.wantText)
+			}
+			if chunk.NextStart != 120 || !chunk.MoreData {
+				t.Errorf("NextStart = %d, MoreData = %v, want 120, true", chunk.NextStart, chunk.MoreData)
+			}
+			if chunk.StartLine != tt.wantStartLine || chunk.Lines != tt.wantLines {
+				t.Errorf("StartLine = %d, Lines = %d, want %d, %d", chunk.StartLine, chunk.Lines, tt.wantStartLine, tt.wantLines)
+			}
+			if chunk.Truncated != tt.wantTruncated {
+				t.Errorf("Truncated = %v, want %v", chunk.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestGetBuildLogProgressiveLargeLog(t *testing.T) {
+	// Lines "line 0000001\n" ... make the log well over maxLogChunkBytes
+	var b strings.Builder
+	for i := 1; b.Len() <= 2*maxLogChunkBytes; i++ {
+		fmt.Fprintf(&b, "line %07d\n", i)
+	}
+	fullLog := b.String()
+
+	var starts []int64
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		starts = append(starts, start)
+		w.Header().Set("X-Text-Size", strconv.Itoa(len(fullLog)))
+		w.Write([]byte(fullLog[start:]))
+	}))
+	ctx := context.Background()
+
+	t.Run("capped chunks", func(t *testing.T) {
+		var text strings.Builder
+		var start int64
+		for calls := 0; ; calls++ {
+			if calls > 3 {
+				t.Fatal("log not read completely after 3 chunks")
+			}
+			chunk, err := client.GetBuildLogProgressive(ctx, "app", 1, start, 0)
+			if err != nil {
+				t.Fatalf("GetBuildLogProgressive() error = %v", err)
+			}
+			if len(chunk.Text) > maxLogChunkBytes || !strings.HasSuffix(chunk.Text, "\n") {
+				t.Fatalf("chunk of %d bytes, want at most %d bytes of complete lines", len(chunk.Text), maxLogChunkBytes)
+			}
+			text.WriteString(chunk.Text)
+			if !chunk.MoreData {
+				break
+			}
+			start = chunk.NextStart
+		}
+		if text.String() != fullLog {
+			t.Errorf("chunks joined to %d bytes, want the %d byte log", text.Len(), len(fullLog))
+		}
+	})
+
+	t.Run("tail reads from the end", func(t *testing.T) {
+		starts = nil
+		chunk, err := client.GetBuildLogProgressive(ctx, "app", 1, 0, 2)
+		if err != nil {
+			t.Fatalf("GetBuildLogProgressive() error = %v", err)
+		}
+		if want := fullLog[len(fullLog)-26:]; chunk.Text != want {
+			t.Errorf("Text = %q, want %q", chunk.Text, want)
+		}
+		if chunk.StartLine != 0 || !chunk.Truncated || chunk.NextStart != int64(len(fullLog)) {
+			t.Errorf("StartLine = %d, Truncated = %v, NextStart = %d, want 0, true, %d", chunk.StartLine, chunk.Truncated, chunk.NextStart, len(fullLog))
+		}
+		if want := []int64{0, int64(len(fullLog) - maxLogChunkBytes)}; len(starts) != 2 || starts[1] != want[1] {
+			t.Errorf("requested offsets %v, want %v", starts, want)
+		}
+	})
+}
+
+func TestTailReader(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		b.WriteString("some log output\n")
+	}
+	b.WriteString("BUILD FAILED\n")
+
+	lines, total, err := tailReader(strings.NewReader(b.String()), 1)
+	if err != nil {
+		t.Fatalf("tailReader() error = %v", err)
+	}
+	if total != 1001 {
+		t.Errorf("total = %d, want 1001", total)
+	}
+	if len(lines) != 1 || lines[0] != "BUILD FAILED\n" {
+		t.Errorf("lines = %q, want [\"BUILD FAILED\\n\"]", lines)
+	}
+}
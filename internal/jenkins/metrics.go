@@ -0,0 +1,51 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+// CSRF crumb issuer path and the endpoint label of its requests
+const (
+	crumbIssuerPath = "/crumbIssuer/api/json"
+	crumbEndpoint   = "crumb"
+)
+
+// endpointContextKey carries the metrics endpoint label of a request
+type endpointContextKey struct{}
+
+// withEndpoint returns a context that labels the requests made with it
+func withEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointContextKey{}, endpoint)
+}
+
+// endpointFromContext returns the endpoint label set by withEndpoint
+func endpointFromContext(ctx context.Context) string {
+	if endpoint, ok := ctx.Value(endpointContextKey{}).(string); ok {
+		return endpoint
+	}
+	return "other"
+}
+
+// requestEndpoint names the Jenkins endpoint a request goes to with a label
+// of bounded cardinality. Reads use the endpoint classes of the response
+// cache; mutations are named after their action, e.g. "stop" or
+// "buildWithParameters".
+func requestEndpoint(method, path string) string {
+	p, _, _ := strings.Cut(path, "?")
+
+	if method != http.MethodGet {
+		return p[strings.LastIndex(p, "/")+1:]
+	}
+
+	if strings.Contains(p, "/logText/") {
+		return config.CacheEndpointLog
+	}
+	if endpoint := cacheEndpoint(path); endpoint != "" {
+		return endpoint
+	}
+	return "other"
+}
@@ -0,0 +1,88 @@
+package jenkins
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/metrics"
+)
+
+func TestClientMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/crumbIssuer/api/json":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&config.Config{
+		JenkinsURL:   server.URL,
+		Username:     "admin",
+		Password:     "password",
+		Timeout:      5 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+		InstanceName: "metrics-test",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.GetJob(ctx, "team/app"); !IsErrorCode(err, ErrorCodeJenkinsError) {
+		t.Fatalf("GetJob() error = %v, want %s", err, ErrorCodeJenkinsError)
+	}
+	if err := client.CancelQueueItem(ctx, 7); err != nil {
+		t.Fatalf("CancelQueueItem() failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Result().Body)
+
+	for _, want := range []string{
+		`jenkins_mcp_jenkins_requests_total{code="503",endpoint="job",instance="metrics-test",method="GET"} 3`,
+		`jenkins_mcp_jenkins_retries_total{endpoint="job",instance="metrics-test"} 2`,
+		`jenkins_mcp_jenkins_requests_total{code="403",endpoint="crumb",instance="metrics-test",method="GET"} 1`,
+		`jenkins_mcp_jenkins_requests_total{code="200",endpoint="cancelItem",instance="metrics-test",method="POST"} 1`,
+		`jenkins_mcp_jenkins_crumb_failures_total{instance="metrics-test"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
+
+func TestRequestEndpoint(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{method: http.MethodGet, path: "/job/app/api/json", want: "job"},
+		{method: http.MethodGet, path: "/job/app/3/api/json", want: "build"},
+		{method: http.MethodGet, path: "/job/app/3/logText/progressiveText?start=0", want: "log"},
+		{method: http.MethodGet, path: "/computer/api/json?tree=computer[displayName]", want: "node"},
+		{method: http.MethodGet, path: "/job/app/lastBuild/api/json", want: "other"},
+		{method: http.MethodPost, path: "/job/app/buildWithParameters?x=1", want: "buildWithParameters"},
+		{method: http.MethodPost, path: "/job/app/3/stop", want: "stop"},
+		{method: http.MethodPost, path: "/createView?name=v", want: "createView"},
+	}
+
+	for _, tt := range tests {
+		if got := requestEndpoint(tt.method, tt.path); got != tt.want {
+			t.Errorf("requestEndpoint(%s, %q) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package jenkins
 
+import "time"
+
 // Job represents basic job information
 type Job struct {
+	Class       string `json:"_class,omitempty"`
 	Name        string `json:"name"`
+	FullName    string `json:"fullName,omitempty"`
 	URL         string `json:"url"`
 	Description string `json:"description"`
 	Buildable   bool   `json:"buildable"`
@@ -24,20 +28,158 @@ type JobDetails struct {
 type JobParameter struct {
 	Name         string      `json:"name"`
 	Type         string      `json:"type"`
+	Kind         string      `json:"kind"` // Kind of value the parameter takes, see the ParameterKind constants
 	DefaultValue interface{} `json:"defaultValue,omitempty"`
 	Description  string      `json:"description,omitempty"`
+	Choices      []string    `json:"choices,omitempty"` // Allowed values of a choice parameter
+}
+
+// IsSecret reports whether the parameter's values must be kept secret
+func (p JobParameter) IsSecret() bool {
+	return p.Kind == ParameterKindPassword
+}
+
+// BuildFile is a file uploaded for a file build parameter
+type BuildFile struct {
+	FileName string // Name the file is uploaded as
+	Content  []byte
 }
 
 // Build represents build information
 type Build struct {
-	Number            int    `json:"number"`
-	URL               string `json:"url"`
-	Result            string `json:"result"` // SUCCESS, FAILURE, ABORTED, etc.
-	Building          bool   `json:"building"`
-	Duration          int64  `json:"duration"`
-	Timestamp         int64  `json:"timestamp"`
-	Executor          string `json:"executor,omitempty"`
-	EstimatedDuration int64  `json:"estimatedDuration,omitempty"`
+	Number            int           `json:"number"`
+	URL               string        `json:"url"`
+	Result            string        `json:"result"` // SUCCESS, FAILURE, ABORTED, etc.
+	Building          bool          `json:"building"`
+	Duration          int64         `json:"duration"`
+	Timestamp         int64         `json:"timestamp"`
+	Executor          string        `json:"executor,omitempty"` // executor slot of a running build, e.g. "#0", or "one-off" for flyweight tasks
+	EstimatedDuration int64         `json:"estimatedDuration,omitempty"`
+	Causes            []BuildCause  `json:"causes,omitempty"`
+	ChangeSets        []ChangeSet   `json:"changeSets,omitempty"`
+	Culprits          []string      `json:"culprits,omitempty"`  // Full names of the users whose changes may have broken the build
+	Revisions         []SCMRevision `json:"revisions,omitempty"` // Revisions checked out, one per repository
+}
+
+// Kinds of build causes
+const (
+	CauseKindUser     = "user"
+	CauseKindSCM      = "scm"
+	CauseKindUpstream = "upstream"
+	CauseKindTimer    = "timer"
+	CauseKindOther    = "other"
+)
+
+// BuildCause describes why a build was started
+type BuildCause struct {
+	Kind             string `json:"kind,omitempty"` // See the CauseKind constants
+	ShortDescription string `json:"shortDescription"`
+	UserID           string `json:"userId,omitempty"`
+	UserName         string `json:"userName,omitempty"`
+	UpstreamProject  string `json:"upstreamProject,omitempty"` // Full name of the job whose build triggered this one
+	UpstreamBuild    int    `json:"upstreamBuild,omitempty"`
+	UpstreamURL      string `json:"upstreamUrl,omitempty"`
+}
+
+// ChangeSet is the list of SCM changes a build picked up from one repository
+type ChangeSet struct {
+	Kind  string   `json:"kind,omitempty"` // SCM kind, e.g. "git"
+	Items []Change `json:"items"`
+}
+
+// Change is a single commit of a changeset
+type Change struct {
+	CommitID      string   `json:"commitId"`
+	Author        string   `json:"author,omitempty"`
+	AuthorEmail   string   `json:"authorEmail,omitempty"`
+	Message       string   `json:"message"`
+	Timestamp     int64    `json:"timestamp,omitempty"`
+	AffectedPaths []string `json:"affectedPaths,omitempty"`
+}
+
+// SCMRevision is a revision a build checked out, as recorded in the Git plugin's BuildData
+type SCMRevision struct {
+	SHA1       string   `json:"sha1"`
+	Branches   []string `json:"branches,omitempty"`
+	RemoteURLs []string `json:"remoteUrls,omitempty"`
+}
+
+// ChangeDiff lists the SCM changes between two builds of a job
+type ChangeDiff struct {
+	JobName       string         `json:"jobName"`
+	FromBuild     int            `json:"fromBuild"`
+	ToBuild       int            `json:"toBuild"`
+	FromRevisions []SCMRevision  `json:"fromRevisions,omitempty"`
+	ToRevisions   []SCMRevision  `json:"toRevisions,omitempty"`
+	Builds        []BuildChanges `json:"builds"`            // Builds after fromBuild up to toBuild, oldest first
+	Commits       int            `json:"commits"`           // Number of distinct commits
+	Authors       []string       `json:"authors,omitempty"` // Distinct commit authors
+	Missing       []int          `json:"missing,omitempty"` // Builds in the range that no longer exist
+}
+
+// BuildChanges are the changes one build picked up
+type BuildChanges struct {
+	Number  int      `json:"number"`
+	Result  string   `json:"result"`
+	Changes []Change `json:"changes"`
+}
+
+// BuildSummary is a build as listed in a job's build history
+type BuildSummary struct {
+	Number      int               `json:"number"`
+	DisplayName string            `json:"displayName,omitempty"`
+	URL         string            `json:"url"`
+	Result      string            `json:"result"` // Empty while the build is running
+	Building    bool              `json:"building"`
+	Timestamp   int64             `json:"timestamp"`
+	Duration    int64             `json:"duration"`
+	Causes      []BuildCause      `json:"causes,omitempty"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+}
+
+// ListBuildsOptions filters and pages the builds returned by ListBuilds
+type ListBuildsOptions struct {
+	Result     string            // Build result to match; RUNNING matches builds in progress
+	Since      time.Time         // Only builds started at or after this time
+	Until      time.Time         // Only builds started before this time
+	User       string            // User ID or name of the user who started the build
+	Parameters map[string]string // Parameter values that must all match
+	Limit      int               // Maximum number of builds to return
+	Cursor     string            // NextCursor of the previous page
+}
+
+// BuildPage is one page of a job's build history, newest first
+type BuildPage struct {
+	Builds     []BuildSummary `json:"builds"`
+	NextCursor string         `json:"nextCursor,omitempty"` // Pass as cursor to continue; empty on the last page
+	Scanned    int            `json:"scanned"`              // Number of builds examined for this page
+}
+
+// BuildInputs are the parameter values and causes a build was started with
+type BuildInputs struct {
+	Number     int               `json:"number"`
+	URL        string            `json:"url"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Causes     []BuildCause      `json:"causes,omitempty"`
+	Unreported []string          `json:"unreported,omitempty"` // Parameters whose values Jenkins does not report, such as passwords and files
+}
+
+// Rebuild describes a build started again from an earlier build, either
+// re-triggered with its parameters or replayed with modified pipeline scripts
+type Rebuild struct {
+	SourceBuild     int          `json:"sourceBuild"`
+	SourceCauses    []BuildCause `json:"sourceCauses,omitempty"`
+	Replayed        bool         `json:"replayed"`
+	QueueItem       *QueueItem   `json:"queueItem,omitempty"`       // Queue item of a re-triggered build; Jenkins does not report one for replays
+	NextBuildNumber int          `json:"nextBuildNumber,omitempty"` // Number a replayed build is expected to get
+	Defaulted       []string     `json:"defaulted,omitempty"`       // Parameters that fall back to the job default because their values are unreported
+	Dropped         []string     `json:"dropped,omitempty"`         // Parameters of the source build the job no longer defines
+}
+
+// ReplayScripts are the pipeline scripts of a build as offered for replay
+type ReplayScripts struct {
+	MainScript    string            `json:"mainScript"`
+	LoadedScripts map[string]string `json:"loadedScripts,omitempty"` // Scripts loaded with the load step, by replay field name
 }
 
 // BuildReference represents a reference to a build
@@ -56,6 +198,8 @@ type QueueItem struct {
 	Stuck        bool              `json:"stuck"`
 	InQueueSince int64             `json:"inQueueSince"`
 	Parameters   map[string]string `json:"parameters,omitempty"`
+	Cancelled    bool              `json:"cancelled,omitempty"`
+	Executable   *BuildReference   `json:"executable,omitempty"` // Set once the item has left the queue and started building
 }
 
 // Artifact represents a build artifact
@@ -65,14 +209,18 @@ type Artifact struct {
 	Size         int64  `json:"size"`
 }
 
-// RunningBuild represents a currently running build
+// RunningBuild represents a currently running build and the executor running it
 type RunningBuild struct {
 	JobName           string `json:"jobName"`
 	BuildNumber       int    `json:"buildNumber"`
+	DisplayName       string `json:"displayName,omitempty"`
 	URL               string `json:"url"`
 	Timestamp         int64  `json:"timestamp"`
 	EstimatedDuration int64  `json:"estimatedDuration"`
-	Executor          string `json:"executor,omitempty"`
+	ElapsedTime       int64  `json:"elapsedTime"`        // milliseconds since the build started
+	Progress          int    `json:"progress"`           // percentage of the estimated duration, -1 when unknown
+	Node              string `json:"node"`               // display name of the node the build runs on
+	Executor          string `json:"executor,omitempty"` // executor slot, e.g. "#0", or "one-off" for flyweight tasks
 }
 
 // View represents a Jenkins view
@@ -89,3 +237,245 @@ type ViewDetails struct {
 	Description string `json:"description,omitempty"`
 	Jobs        []Job  `json:"jobs"`
 }
+
+// Node represents a Jenkins node (agent or the built-in node)
+type Node struct {
+	Name               string         `json:"name"` // Name used to address the node, "(built-in)" for the controller
+	DisplayName        string         `json:"displayName"`
+	Description        string         `json:"description,omitempty"`
+	Offline            bool           `json:"offline"`
+	TemporarilyOffline bool           `json:"temporarilyOffline"` // Taken offline by a user rather than disconnected
+	OfflineCause       string         `json:"offlineCause,omitempty"`
+	Idle               bool           `json:"idle"`
+	NumExecutors       int            `json:"numExecutors"`
+	BusyExecutors      int            `json:"busyExecutors"`
+	Labels             []string       `json:"labels,omitempty"`
+	Monitors           *NodeMonitors  `json:"monitors,omitempty"`
+	RunningBuilds      []RunningBuild `json:"runningBuilds,omitempty"` // Builds running on the node's busy executors
+}
+
+// NodeMonitors holds the latest node monitor readings of a node.
+// Sizes are in bytes and times in milliseconds; zero means not reported.
+type NodeMonitors struct {
+	Architecture            string `json:"architecture,omitempty"`
+	DiskSpaceFree           int64  `json:"diskSpaceFree,omitempty"` // Free space in the node's root directory
+	DiskSpacePath           string `json:"diskSpacePath,omitempty"`
+	TempSpaceFree           int64  `json:"tempSpaceFree,omitempty"`
+	AvailableSwapSpace      int64  `json:"availableSwapSpace,omitempty"`
+	TotalSwapSpace          int64  `json:"totalSwapSpace,omitempty"`
+	AvailablePhysicalMemory int64  `json:"availablePhysicalMemory,omitempty"`
+	TotalPhysicalMemory     int64  `json:"totalPhysicalMemory,omitempty"`
+	ResponseTime            int64  `json:"responseTime,omitempty"`    // Average round-trip time to the agent
+	ClockDifference         int64  `json:"clockDifference,omitempty"` // Clock offset from the controller
+}
+
+// Branch kinds reported for multibranch child jobs
+const (
+	BranchKindBranch      = "branch"
+	BranchKindPullRequest = "pull-request"
+	BranchKindTag         = "tag"
+)
+
+// Branch represents a branch, pull request or tag job of a multibranch project
+type Branch struct {
+	Name        string `json:"name"`
+	FullName    string `json:"fullName"`
+	DisplayName string `json:"displayName,omitempty"`
+	URL         string `json:"url"`
+	Kind        string `json:"kind"`
+	Color       string `json:"color"`
+	Title       string `json:"title,omitempty"`     // PR title or branch display name from the SCM
+	ObjectURL   string `json:"objectUrl,omitempty"` // Link to the branch or PR in the SCM
+	LastBuild   *Build `json:"lastBuild,omitempty"`
+}
+
+// BranchSource represents an SCM source or navigator configured on a multibranch
+// project or organization folder
+type BranchSource struct {
+	Class         string `json:"class"`
+	ID            string `json:"id,omitempty"`
+	Remote        string `json:"remote,omitempty"`
+	ServerURL     string `json:"serverUrl,omitempty"`
+	APIURI        string `json:"apiUri,omitempty"`
+	RepoOwner     string `json:"repoOwner,omitempty"`
+	Repository    string `json:"repository,omitempty"`
+	RepositoryURL string `json:"repositoryUrl,omitempty"`
+	CredentialsID string `json:"credentialsId,omitempty"`
+}
+
+// MultibranchConfig represents the branch source configuration of a multibranch
+// project or organization folder
+type MultibranchConfig struct {
+	Class      string         `json:"class"`
+	Sources    []BranchSource `json:"sources"`
+	ScriptPath string         `json:"scriptPath,omitempty"`
+}
+
+// LogChunk represents a portion of a build console log read via the progressive text API
+type LogChunk struct {
+	Text      string `json:"text"`
+	Start     int64  `json:"start"`     // Offset the chunk was read from
+	NextStart int64  `json:"nextStart"` // Offset to pass as start to continue reading
+	MoreData  bool   `json:"moreData"`  // True while the build is still producing output or more log text remains to be read
+	StartLine int    `json:"startLine"` // 1-based line number (relative to start) of the first returned line; 0 when only the end of a long log was read
+	Lines     int    `json:"lines"`     // Number of lines returned
+	Truncated bool   `json:"truncated"` // True when earlier lines were dropped in tail mode
+}
+
+// Wait phases reported by WaitForBuild
+const (
+	WaitPhaseQueued    = "queued"
+	WaitPhaseBuilding  = "building"
+	WaitPhaseCompleted = "completed"
+)
+
+// WaitProgress describes the state of a build being waited on
+type WaitProgress struct {
+	Phase             string        `json:"phase"`
+	QueueID           int           `json:"queueId"`
+	BuildNumber       int           `json:"buildNumber,omitempty"`
+	Why               string        `json:"why,omitempty"` // Reason the item is still queued
+	Elapsed           time.Duration `json:"elapsed"`
+	EstimatedDuration time.Duration `json:"estimatedDuration,omitempty"`
+}
+
+// WaitOptions configures how WaitForBuild polls Jenkins
+type WaitOptions struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	OnProgress   func(WaitProgress) // Called after every poll; may be nil
+}
+
+// Stage statuses reported by the Pipeline Stage View (wfapi)
+const (
+	StageStatusSuccess    = "SUCCESS"
+	StageStatusFailed     = "FAILED"
+	StageStatusUnstable   = "UNSTABLE"
+	StageStatusAborted    = "ABORTED"
+	StageStatusInProgress = "IN_PROGRESS"
+)
+
+// PipelineRun represents a pipeline build as described by the Workflow API
+type PipelineRun struct {
+	ID                  string  `json:"id"`
+	Name                string  `json:"name"`
+	Status              string  `json:"status"`
+	StartTimeMillis     int64   `json:"startTimeMillis"`
+	EndTimeMillis       int64   `json:"endTimeMillis"`
+	DurationMillis      int64   `json:"durationMillis"`
+	QueueDurationMillis int64   `json:"queueDurationMillis"`
+	Stages              []Stage `json:"stages"`
+	FailedStage         *Stage  `json:"failedStage,omitempty"` // First stage with FAILED status
+}
+
+// Stage represents a single stage of a pipeline run
+type Stage struct {
+	ID                  string      `json:"id"`
+	Name                string      `json:"name"`
+	Status              string      `json:"status"`
+	StartTimeMillis     int64       `json:"startTimeMillis"`
+	DurationMillis      int64       `json:"durationMillis"`
+	PauseDurationMillis int64       `json:"pauseDurationMillis"`
+	Error               *StageError `json:"error,omitempty"`
+}
+
+// StageError describes why a stage or step failed
+type StageError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// StageLog represents the console output of a pipeline stage
+type StageLog struct {
+	StageID   string    `json:"stageId"`
+	StageName string    `json:"stageName"`
+	Status    string    `json:"status"`
+	Steps     []StepLog `json:"steps"`
+}
+
+// StepLog represents the console output of a single step within a stage
+type StepLog struct {
+	NodeID      string      `json:"nodeId"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Status      string      `json:"status"`
+	Error       *StageError `json:"error,omitempty"`
+	Text        string      `json:"text"`
+	HasMore     bool        `json:"hasMore"` // True when Jenkins truncated the step log
+}
+
+// Test case statuses reported by the JUnit plugin
+const (
+	TestStatusPassed     = "PASSED"
+	TestStatusFixed      = "FIXED"
+	TestStatusSkipped    = "SKIPPED"
+	TestStatusFailed     = "FAILED"
+	TestStatusRegression = "REGRESSION"
+)
+
+// TestReport represents the JUnit test results of a build
+type TestReport struct {
+	Duration  float64     `json:"duration"`
+	FailCount int         `json:"failCount"`
+	PassCount int         `json:"passCount"`
+	SkipCount int         `json:"skipCount"`
+	Suites    []TestSuite `json:"suites"`
+}
+
+// TestSuite represents a single test suite in a test report
+type TestSuite struct {
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases"`
+}
+
+// TestCase represents a single test case result
+type TestCase struct {
+	ClassName       string  `json:"className"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Duration        float64 `json:"duration"`
+	ErrorDetails    string  `json:"errorDetails,omitempty"`
+	ErrorStackTrace string  `json:"errorStackTrace,omitempty"`
+	Age             int     `json:"age,omitempty"`         // Number of consecutive builds the test has been failing
+	FailedSince     int     `json:"failedSince,omitempty"` // Build number in which the test started failing
+}
+
+// TestReportSummary is a compact view of a test report listing only failing tests
+type TestReportSummary struct {
+	Total       int        `json:"total"`
+	PassCount   int        `json:"passCount"`
+	FailCount   int        `json:"failCount"`
+	SkipCount   int        `json:"skipCount"`
+	Duration    float64    `json:"duration"`
+	FailedTests []TestCase `json:"failedTests"`
+	Truncated   bool       `json:"truncated"` // True when more tests matched than were returned
+}
+
+// BuildDiagnosis is a compact summary of why a build failed
+type BuildDiagnosis struct {
+	Build         *Build             `json:"build"`
+	FailedStage   *Stage             `json:"failedStage,omitempty"`
+	Tests         *TestReportSummary `json:"tests,omitempty"`
+	LogExcerpts   []LogExcerpt       `json:"logExcerpts"`
+	LogLines      int                `json:"logLines,omitempty"`      // Total number of lines in the console log; 0 when only the end of a long log was read
+	LogBytes      int64              `json:"logBytes,omitempty"`      // Size of the console log, reported when LogLines is unknown
+	LinesFromTail bool               `json:"linesFromTail,omitempty"` // True when excerpt line numbers count from the first scanned line rather than the start of the log
+	Notes         []string           `json:"notes,omitempty"`
+}
+
+// LogExcerpt is a window of console log lines around an error marker
+type LogExcerpt struct {
+	StartLine int    `json:"startLine"` // 1-based line number of the first line in Text (see BuildDiagnosis.LinesFromTail)
+	EndLine   int    `json:"endLine"`
+	Marker    string `json:"marker"` // The error marker that matched
+	Text      string `json:"text"`
+}
+
+// DiagnoseOptions configures how much data DiagnoseBuild collects
+type DiagnoseOptions struct {
+	TailLines    int  // Number of log lines from the end of the log to scan
+	ContextLines *int // Lines of context kept around each error marker; nil for the default
+	MaxExcerpts  int  // Maximum number of log excerpts returned
+	MaxTests     int  // Maximum number of failing tests returned
+}
@@ -0,0 +1,264 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// multibranchViewKinds maps the views Jenkins creates on multibranch projects to branch kinds
+var multibranchViewKinds = map[string]string{
+	"branches":        BranchKindBranch,
+	"change-requests": BranchKindPullRequest,
+	"tags":            BranchKindTag,
+}
+
+// xmlDeclPattern matches the XML declaration; Jenkins emits version 1.1 which encoding/xml rejects
+var xmlDeclPattern = regexp.MustCompile(`^\s*<\?xml[^>]*\?>`)
+
+// ListBranches lists the branch, pull request and tag jobs of a multibranch project
+// together with the latest build of each
+func (c *Client) ListBranches(ctx context.Context, jobName string) ([]Branch, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+
+	// Build the API path with branch jobs and the kind views in a single request
+	path := jobPath(jobName) + "/api/json"
+	path += "?tree=_class,"
+	path += "jobs[name,fullName,displayName,url,color,"
+	path += "actions[objectDisplayName,objectUrl],"
+	path += "lastBuild[number,url,result,building,duration,timestamp,estimatedDuration]],"
+	path += "views[name,jobs[name]]"
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	var rawResult struct {
+		Class string `json:"_class"`
+		Jobs  []struct {
+			Name        string `json:"name"`
+			FullName    string `json:"fullName"`
+			DisplayName string `json:"displayName"`
+			URL         string `json:"url"`
+			Color       string `json:"color"`
+			Actions     []struct {
+				ObjectDisplayName string `json:"objectDisplayName"`
+				ObjectURL         string `json:"objectUrl"`
+			} `json:"actions"`
+			LastBuild *Build `json:"lastBuild"`
+		} `json:"jobs"`
+		Views []struct {
+			Name string `json:"name"`
+			Jobs []struct {
+				Name string `json:"name"`
+			} `json:"jobs"`
+		} `json:"views"`
+	}
+
+	if err := json.Unmarshal(body, &rawResult); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	if !strings.Contains(rawResult.Class, "MultiBranch") {
+		return nil, NewInvalidInputError(fmt.Sprintf("job '%s' is not a multibranch project (class: %s)", jobName, rawResult.Class))
+	}
+
+	// Work out the kind of each child job from the view it is listed in
+	kinds := make(map[string]string)
+	for _, view := range rawResult.Views {
+		kind, ok := multibranchViewKinds[view.Name]
+		if !ok {
+			continue
+		}
+		for _, job := range view.Jobs {
+			kinds[job.Name] = kind
+		}
+	}
+
+	branches := make([]Branch, 0, len(rawResult.Jobs))
+	for _, job := range rawResult.Jobs {
+		branch := Branch{
+			Name:        job.Name,
+			FullName:    job.FullName,
+			DisplayName: job.DisplayName,
+			URL:         job.URL,
+			Kind:        BranchKindBranch,
+			Color:       job.Color,
+			LastBuild:   job.LastBuild,
+		}
+		if kind, ok := kinds[job.Name]; ok {
+			branch.Kind = kind
+		}
+		if branch.FullName == "" {
+			branch.FullName = strings.Trim(jobName, "/") + "/" + job.Name
+		}
+
+		// ObjectMetadataAction carries the PR title and SCM link
+		for _, action := range job.Actions {
+			if action.ObjectDisplayName != "" || action.ObjectURL != "" {
+				branch.Title = action.ObjectDisplayName
+				branch.ObjectURL = action.ObjectURL
+				break
+			}
+		}
+
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// ScanMultibranch triggers a branch scan (reindex) of a multibranch project or organization folder
+func (c *Client) ScanMultibranch(ctx context.Context, jobName string) error {
+	if jobName == "" {
+		return NewInvalidInputError("job name cannot be empty")
+	}
+
+	// Scheduling a build of a multibranch project or organization folder runs branch indexing
+	path := jobPath(jobName) + "/build?delay=0"
+
+	// Make POST request
+	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusFound {
+		return NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	return nil
+}
+
+// GetBranchSources reads the branch source configuration of a multibranch project
+// or the SCM navigators of an organization folder from its config.xml
+func (c *Client) GetBranchSources(ctx context.Context, jobName string) (*MultibranchConfig, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+
+	path := jobPath(jobName) + "/config.xml"
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	configBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read config.xml", err)
+	}
+
+	return parseMultibranchConfig(configBytes)
+}
+
+// scmSourceXML holds the fields shared by the common SCM source and navigator implementations
+type scmSourceXML struct {
+	XMLName       xml.Name
+	Class         string `xml:"class,attr"`
+	ID            string `xml:"id"`
+	Remote        string `xml:"remote"`
+	ServerURL     string `xml:"serverUrl"`
+	APIURI        string `xml:"apiUri"`
+	RepoOwner     string `xml:"repoOwner"`
+	Repository    string `xml:"repository"`
+	RepositoryURL string `xml:"repositoryUrl"`
+	CredentialsID string `xml:"credentialsId"`
+}
+
+func (s scmSourceXML) toBranchSource() BranchSource {
+	class := s.Class
+	if class == "" {
+		class = s.XMLName.Local
+	}
+	return BranchSource{
+		Class:         class,
+		ID:            s.ID,
+		Remote:        s.Remote,
+		ServerURL:     s.ServerURL,
+		APIURI:        s.APIURI,
+		RepoOwner:     s.RepoOwner,
+		Repository:    s.Repository,
+		RepositoryURL: s.RepositoryURL,
+		CredentialsID: s.CredentialsID,
+	}
+}
+
+// parseMultibranchConfig extracts branch sources from a multibranch or organization folder config.xml
+func parseMultibranchConfig(data []byte) (*MultibranchConfig, error) {
+	var raw struct {
+		XMLName xml.Name
+		Sources []struct {
+			Source scmSourceXML `xml:"source"`
+		} `xml:"sources>data>jenkins.branch.BranchSource"`
+		Navigators struct {
+			Items []scmSourceXML `xml:",any"`
+		} `xml:"navigators"`
+		Factory struct {
+			ScriptPath string `xml:"scriptPath"`
+		} `xml:"factory"`
+		ProjectFactories struct {
+			Items []struct {
+				ScriptPath string `xml:"scriptPath"`
+			} `xml:",any"`
+		} `xml:"projectFactories"`
+	}
+
+	if err := xml.Unmarshal(xmlDeclPattern.ReplaceAll(data, nil), &raw); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse config.xml", err)
+	}
+
+	if len(raw.Sources) == 0 && len(raw.Navigators.Items) == 0 {
+		return nil, NewInvalidInputError(fmt.Sprintf("job is not a multibranch project or organization folder (%s)", raw.XMLName.Local))
+	}
+
+	cfg := &MultibranchConfig{
+		Class:      raw.XMLName.Local,
+		Sources:    make([]BranchSource, 0, len(raw.Sources)+len(raw.Navigators.Items)),
+		ScriptPath: raw.Factory.ScriptPath,
+	}
+	for _, src := range raw.Sources {
+		cfg.Sources = append(cfg.Sources, src.Source.toBranchSource())
+	}
+	for _, nav := range raw.Navigators.Items {
+		cfg.Sources = append(cfg.Sources, nav.toBranchSource())
+	}
+	if cfg.ScriptPath == "" {
+		for _, factory := range raw.ProjectFactories.Items {
+			if factory.ScriptPath != "" {
+				cfg.ScriptPath = factory.ScriptPath
+				break
+			}
+		}
+	}
+
+	return cfg, nil
+}
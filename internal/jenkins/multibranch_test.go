@@ -0,0 +1,154 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseMultibranchConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		xml            string
+		wantErr        bool
+		wantClass      string
+		wantSources    int
+		wantScriptPath string
+		wantFirst      BranchSource
+	}{
+		{
+			name: "git branch source",
+			xml: `<?xml version='1.1' encoding='UTF-8'?>
+<org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject plugin="workflow-multibranch@2.26">
+  <sources class="jenkins.branch.MultiBranchProject$BranchSourceList">
+    <data>
+      <jenkins.branch.BranchSource>
+        <source class="jenkins.plugins.git.GitSCMSource" plugin="git@5.0">
+          <id>b3f1</id>
+          <remote>https://git.example.com/team/service.git</remote>
+          <credentialsId>git-creds</credentialsId>
+        </source>
+      </jenkins.branch.BranchSource>
+    </data>
+  </sources>
+  <factory class="org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory">
+    <scriptPath>ci/Jenkinsfile</scriptPath>
+  </factory>
+</org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject>`,
+			wantClass:      "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject",
+			wantSources:    1,
+			wantScriptPath: "ci/Jenkinsfile",
+			wantFirst: BranchSource{
+				Class:         "jenkins.plugins.git.GitSCMSource",
+				ID:            "b3f1",
+				Remote:        "https://git.example.com/team/service.git",
+				CredentialsID: "git-creds",
+			},
+		},
+		{
+			name: "organization folder navigator",
+			xml: `<?xml version='1.1' encoding='UTF-8'?>
+<jenkins.branch.OrganizationFolder plugin="branch-api@2.1">
+  <navigators>
+    <org.jenkinsci.plugins.github__branch__source.GitHubSCMNavigator plugin="github-branch-source@1.0">
+      <repoOwner>my-org</repoOwner>
+      <apiUri>https://api.github.com</apiUri>
+      <credentialsId>gh-app</credentialsId>
+    </org.jenkinsci.plugins.github__branch__source.GitHubSCMNavigator>
+  </navigators>
+  <projectFactories>
+    <org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProjectFactory>
+      <scriptPath>Jenkinsfile</scriptPath>
+    </org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProjectFactory>
+  </projectFactories>
+</jenkins.branch.OrganizationFolder>`,
+			wantClass:      "jenkins.branch.OrganizationFolder",
+			wantSources:    1,
+			wantScriptPath: "Jenkinsfile",
+			wantFirst: BranchSource{
+				Class:         "org.jenkinsci.plugins.github__branch__source.GitHubSCMNavigator",
+				APIURI:        "https://api.github.com",
+				RepoOwner:     "my-org",
+				CredentialsID: "gh-app",
+			},
+		},
+		{
+			name: "freestyle job",
+			xml: `<?xml version='1.1' encoding='UTF-8'?>
+<project><builders/></project>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseMultibranchConfig([]byte(tt.xml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMultibranchConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Class != tt.wantClass {
+				t.Errorf("Class = %q, want %q", cfg.Class, tt.wantClass)
+			}
+			if cfg.ScriptPath != tt.wantScriptPath {
+				t.Errorf("ScriptPath = %q, want %q", cfg.ScriptPath, tt.wantScriptPath)
+			}
+			if len(cfg.Sources) != tt.wantSources {
+				t.Fatalf("len(Sources) = %d, want %d", len(cfg.Sources), tt.wantSources)
+			}
+			if cfg.Sources[0] != tt.wantFirst {
+				t.Errorf("Sources[0] = %+v, want %+v", cfg.Sources[0], tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestListBranches(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/team/job/service/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"_class": "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject",
+			"jobs": [
+				{"name": "main", "fullName": "team/service/main", "color": "blue",
+				 "lastBuild": {"number": 7, "result": "SUCCESS"}},
+				{"name": "PR-123", "fullName": "team/service/PR-123", "color": "red",
+				 "actions": [{}, {"objectDisplayName": "Fix login", "objectUrl": "https://git.example.com/pr/123"}],
+				 "lastBuild": {"number": 2, "result": "FAILURE"}},
+				{"name": "v1.0.0", "fullName": "team/service/v1.0.0", "color": "notbuilt"}
+			],
+			"views": [
+				{"name": "All", "jobs": [{"name": "main"}, {"name": "PR-123"}, {"name": "v1.0.0"}]},
+				{"name": "branches", "jobs": [{"name": "main"}]},
+				{"name": "change-requests", "jobs": [{"name": "PR-123"}]},
+				{"name": "tags", "jobs": [{"name": "v1.0.0"}]}
+			]
+		}`))
+	}))
+
+	branches, err := client.ListBranches(context.Background(), "team/service")
+	if err != nil {
+		t.Fatalf("ListBranches() error = %v", err)
+	}
+	if len(branches) != 3 {
+		t.Fatalf("ListBranches() returned %d branches, want 3", len(branches))
+	}
+
+	pr := branches[1]
+	if pr.Kind != BranchKindPullRequest {
+		t.Errorf("PR-123 kind = %q, want %q", pr.Kind, BranchKindPullRequest)
+	}
+	if pr.Title != "Fix login" {
+		t.Errorf("PR-123 title = %q, want %q", pr.Title, "Fix login")
+	}
+	if pr.LastBuild == nil || pr.LastBuild.Result != "FAILURE" {
+		t.Errorf("PR-123 last build = %+v, want FAILURE", pr.LastBuild)
+	}
+	if branches[0].Kind != BranchKindBranch || branches[2].Kind != BranchKindTag {
+		t.Errorf("kinds = %q, %q, want %q, %q", branches[0].Kind, branches[2].Kind, BranchKindBranch, BranchKindTag)
+	}
+}
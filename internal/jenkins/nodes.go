@@ -0,0 +1,296 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BuiltInNodeName addresses the controller's built-in node in the computer API
+const BuiltInNodeName = "(built-in)"
+
+// builtInComputerClass is the class of the built-in node's computer
+const builtInComputerClass = "hudson.model.Hudson$MasterComputer"
+
+// Node monitor keys reported in a computer's monitorData
+const (
+	monitorArchitecture = "hudson.node_monitors.ArchitectureMonitor"
+	monitorClock        = "hudson.node_monitors.ClockMonitor"
+	monitorDiskSpace    = "hudson.node_monitors.DiskSpaceMonitor"
+	monitorResponseTime = "hudson.node_monitors.ResponseTimeMonitor"
+	monitorSwapSpace    = "hudson.node_monitors.SwapSpaceMonitor"
+	monitorTempSpace    = "hudson.node_monitors.TemporarySpaceMonitor"
+)
+
+// runningExecutableTree selects the fields of a busy executor and the build it runs
+const runningExecutableTree = "number,progress,currentExecutable[number,url,fullDisplayName,timestamp,estimatedDuration]"
+
+// nodeTree selects the computer fields reported for a node
+const nodeTree = "_class,displayName,description,offline,temporarilyOffline,offlineCauseReason,idle,numExecutors," +
+	"assignedLabels[name],monitorData[*]," +
+	"executors[" + runningExecutableTree + "],oneOffExecutors[" + runningExecutableTree + "]"
+
+// rawExecutor is an executor as returned by the computer API
+type rawExecutor struct {
+	Number            int `json:"number"`
+	Progress          int `json:"progress"`
+	CurrentExecutable *struct {
+		Number            int    `json:"number"`
+		URL               string `json:"url"`
+		FullDisplayName   string `json:"fullDisplayName"`
+		Timestamp         int64  `json:"timestamp"`
+		EstimatedDuration int64  `json:"estimatedDuration"`
+	} `json:"currentExecutable"`
+}
+
+// rawComputer is a computer as returned by the computer API
+type rawComputer struct {
+	Class              string `json:"_class"`
+	DisplayName        string `json:"displayName"`
+	Description        string `json:"description"`
+	Offline            bool   `json:"offline"`
+	TemporarilyOffline bool   `json:"temporarilyOffline"`
+	OfflineCauseReason string `json:"offlineCauseReason"`
+	Idle               bool   `json:"idle"`
+	NumExecutors       int    `json:"numExecutors"`
+	AssignedLabels     []struct {
+		Name string `json:"name"`
+	} `json:"assignedLabels"`
+	MonitorData     map[string]json.RawMessage `json:"monitorData"`
+	Executors       []rawExecutor              `json:"executors"`
+	OneOffExecutors []rawExecutor              `json:"oneOffExecutors"`
+}
+
+// name returns the name used to address the computer in URLs
+func (rc *rawComputer) name() string {
+	if rc.Class == builtInComputerClass {
+		return BuiltInNodeName
+	}
+	return rc.DisplayName
+}
+
+// runningBuilds returns the builds running on the computer's executors. A pipeline
+// appears once for its flyweight task on the controller and once per node block on
+// an agent, so builds already present in seen are reported once, preferring the agent.
+func (rc *rawComputer) runningBuilds(builds []RunningBuild, seen map[string]int, now time.Time) []RunningBuild {
+	add := func(exec rawExecutor, oneOff bool) {
+		build := exec.CurrentExecutable
+		if build == nil || build.URL == "" {
+			return
+		}
+
+		jobName, number := jobNameFromBuildURL(build.URL)
+		if build.Number > 0 {
+			number = build.Number
+		}
+
+		runningBuild := RunningBuild{
+			JobName:           jobName,
+			BuildNumber:       number,
+			DisplayName:       build.FullDisplayName,
+			URL:               build.URL,
+			Timestamp:         build.Timestamp,
+			EstimatedDuration: build.EstimatedDuration,
+			Progress:          exec.Progress,
+			Node:              rc.DisplayName,
+			Executor:          fmt.Sprintf("#%d", exec.Number),
+		}
+		if oneOff {
+			runningBuild.Executor = "one-off"
+		}
+		if build.Timestamp > 0 {
+			runningBuild.ElapsedTime = now.UnixMilli() - build.Timestamp
+		}
+
+		if i, ok := seen[build.URL]; ok {
+			if builds[i].Executor == "one-off" && !oneOff {
+				builds[i] = runningBuild
+			}
+			return
+		}
+		seen[build.URL] = len(builds)
+		builds = append(builds, runningBuild)
+	}
+
+	for _, exec := range rc.Executors {
+		add(exec, false)
+	}
+	for _, exec := range rc.OneOffExecutors {
+		add(exec, true)
+	}
+
+	return builds
+}
+
+// node converts the computer into a Node
+func (rc *rawComputer) node(now time.Time) Node {
+	node := Node{
+		Name:               rc.name(),
+		DisplayName:        rc.DisplayName,
+		Description:        rc.Description,
+		Offline:            rc.Offline,
+		TemporarilyOffline: rc.TemporarilyOffline,
+		OfflineCause:       rc.OfflineCauseReason,
+		Idle:               rc.Idle,
+		NumExecutors:       rc.NumExecutors,
+		Monitors:           parseNodeMonitors(rc.MonitorData),
+	}
+
+	for _, label := range rc.AssignedLabels {
+		node.Labels = append(node.Labels, label.Name)
+	}
+	for _, exec := range rc.Executors {
+		if exec.CurrentExecutable != nil {
+			node.BusyExecutors++
+		}
+	}
+	node.RunningBuilds = rc.runningBuilds(nil, make(map[string]int), now)
+
+	return node
+}
+
+// parseNodeMonitors extracts the known node monitor readings; monitors that
+// have not reported yet or are disabled are null and left at zero
+func parseNodeMonitors(data map[string]json.RawMessage) *NodeMonitors {
+	if len(data) == 0 {
+		return nil
+	}
+
+	monitors := &NodeMonitors{}
+	var disk, temp struct {
+		Path string `json:"path"`
+		Size int64  `json:"size"`
+	}
+	var swap struct {
+		AvailablePhysicalMemory int64 `json:"availablePhysicalMemory"`
+		AvailableSwapSpace      int64 `json:"availableSwapSpace"`
+		TotalPhysicalMemory     int64 `json:"totalPhysicalMemory"`
+		TotalSwapSpace          int64 `json:"totalSwapSpace"`
+	}
+	var responseTime struct {
+		Average int64 `json:"average"`
+	}
+	var clock struct {
+		Diff int64 `json:"diff"`
+	}
+
+	// Readings that are missing or fail to parse are skipped
+	_ = json.Unmarshal(data[monitorArchitecture], &monitors.Architecture)
+	if json.Unmarshal(data[monitorDiskSpace], &disk) == nil {
+		monitors.DiskSpaceFree, monitors.DiskSpacePath = disk.Size, disk.Path
+	}
+	if json.Unmarshal(data[monitorTempSpace], &temp) == nil {
+		monitors.TempSpaceFree = temp.Size
+	}
+	if json.Unmarshal(data[monitorSwapSpace], &swap) == nil {
+		monitors.AvailableSwapSpace = swap.AvailableSwapSpace
+		monitors.TotalSwapSpace = swap.TotalSwapSpace
+		monitors.AvailablePhysicalMemory = swap.AvailablePhysicalMemory
+		monitors.TotalPhysicalMemory = swap.TotalPhysicalMemory
+	}
+	if json.Unmarshal(data[monitorResponseTime], &responseTime) == nil {
+		monitors.ResponseTime = responseTime.Average
+	}
+	if json.Unmarshal(data[monitorClock], &clock) == nil {
+		monitors.ClockDifference = clock.Diff
+	}
+
+	return monitors
+}
+
+// nodePath returns the computer API path of a node. The built-in node is
+// also accepted by its display name or legacy "master" names.
+func nodePath(nodeName string) string {
+	switch strings.ToLower(nodeName) {
+	case "built-in node", "built-in", "master", "(master)":
+		nodeName = BuiltInNodeName
+	}
+	return "/computer/" + url.PathEscape(nodeName)
+}
+
+// GetNode retrieves the details of a single node, including its offline cause,
+// labels, monitor readings and the builds running on it
+func (c *Client) GetNode(ctx context.Context, nodeName string) (*Node, error) {
+	if nodeName == "" {
+		return nil, NewInvalidInputError("node name cannot be empty")
+	}
+
+	path := nodePath(nodeName) + "/api/json?tree=" + nodeTree
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("node %s", nodeName))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	var raw rawComputer
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	node := raw.node(time.Now())
+	return &node, nil
+}
+
+// SetNodeOffline takes a node temporarily offline with the given reason, or
+// brings a temporarily offline node back online. Taking an already offline node
+// offline updates its reason. Running builds are not interrupted; the node just
+// stops accepting new ones.
+func (c *Client) SetNodeOffline(ctx context.Context, nodeName string, offline bool, reason string) error {
+	// toggleOffline flips the state, so it must be decided on the current one
+	node, err := c.GetNode(withoutCache(ctx), nodeName)
+	if err != nil {
+		return err
+	}
+
+	var action string
+	switch {
+	case offline && node.TemporarilyOffline:
+		action = "changeOfflineCause"
+	case offline:
+		action = "toggleOffline"
+	case node.TemporarilyOffline:
+		action = "toggleOffline"
+	case node.Offline:
+		return NewInvalidInputError(fmt.Sprintf("node %s is disconnected rather than marked offline; reconnect its agent to bring it online", nodeName))
+	default:
+		// Already online
+		return nil
+	}
+
+	path := nodePath(node.Name) + "/" + action
+	if offline {
+		path += "?" + url.Values{"offlineMessage": {reason}}.Encode()
+	}
+
+	// Make POST request
+	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors (Jenkins redirects back to the node page on success)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther {
+		return NewHTTPError(resp, fmt.Sprintf("node %s", nodeName))
+	}
+
+	return nil
+}
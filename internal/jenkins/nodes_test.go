@@ -0,0 +1,187 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+const agentComputerJSON = `{
+	"_class": "hudson.slaves.SlaveComputer",
+	"displayName": "agent-1",
+	"offline": true,
+	"temporarilyOffline": true,
+	"offlineCauseReason": "disk cleanup",
+	"idle": false,
+	"numExecutors": 2,
+	"assignedLabels": [{"name": "agent-1"}, {"name": "linux"}, {"name": "docker"}],
+	"monitorData": {
+		"hudson.node_monitors.ArchitectureMonitor": "Linux (amd64)",
+		"hudson.node_monitors.DiskSpaceMonitor": {"_class": "hudson.node_monitors.DiskSpaceMonitorDescriptor$DiskSpace", "path": "/var/jenkins", "size": 1073741824},
+		"hudson.node_monitors.SwapSpaceMonitor": {"availablePhysicalMemory": 2048, "availableSwapSpace": 512, "totalPhysicalMemory": 8192, "totalSwapSpace": 1024},
+		"hudson.node_monitors.ResponseTimeMonitor": {"average": 42},
+		"hudson.node_monitors.TemporarySpaceMonitor": null
+	},
+	"executors": [
+		{"number": 0, "progress": 40, "currentExecutable": {"number": 9, "url": "http://jenkins/job/team/job/app/9/", "timestamp": 1000}},
+		{"number": 1, "progress": -1, "currentExecutable": null}
+	],
+	"oneOffExecutors": []
+}`
+
+func TestGetNode(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/computer/agent-1/api/json":
+			w.Write([]byte(agentComputerJSON))
+		case "/computer/(built-in)/api/json":
+			w.Write([]byte(`{"_class": "hudson.model.Hudson$MasterComputer", "displayName": "Built-In Node", "numExecutors": 0, "idle": true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	node, err := client.GetNode(context.Background(), "agent-1")
+	if err != nil {
+		t.Fatalf("GetNode() error = %v", err)
+	}
+	if node.Name != "agent-1" || node.OfflineCause != "disk cleanup" || !node.TemporarilyOffline {
+		t.Errorf("GetNode() = %+v, want temporarily offline agent-1 with cause", node)
+	}
+	if len(node.Labels) != 3 || node.Labels[1] != "linux" {
+		t.Errorf("Labels = %v, want [agent-1 linux docker]", node.Labels)
+	}
+	if node.BusyExecutors != 1 || len(node.RunningBuilds) != 1 || node.RunningBuilds[0].JobName != "team/app" {
+		t.Errorf("BusyExecutors = %d, RunningBuilds = %+v, want one team/app build", node.BusyExecutors, node.RunningBuilds)
+	}
+	want := NodeMonitors{
+		Architecture:            "Linux (amd64)",
+		DiskSpaceFree:           1073741824,
+		DiskSpacePath:           "/var/jenkins",
+		AvailableSwapSpace:      512,
+		TotalSwapSpace:          1024,
+		AvailablePhysicalMemory: 2048,
+		TotalPhysicalMemory:     8192,
+		ResponseTime:            42,
+	}
+	if node.Monitors == nil || *node.Monitors != want {
+		t.Errorf("Monitors = %+v, want %+v", node.Monitors, want)
+	}
+
+	builtIn, err := client.GetNode(context.Background(), "Built-In Node")
+	if err != nil {
+		t.Fatalf("GetNode(Built-In Node) error = %v", err)
+	}
+	if builtIn.Name != BuiltInNodeName {
+		t.Errorf("built-in Name = %q, want %q", builtIn.Name, BuiltInNodeName)
+	}
+
+	if _, err := client.GetNode(context.Background(), "missing"); !IsErrorCode(err, ErrorCodeNotFound) {
+		t.Errorf("GetNode(missing) error = %v, want %s", err, ErrorCodeNotFound)
+	}
+}
+
+func TestSetNodeOffline(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      string
+		offline    bool
+		wantAction string
+		wantErr    ErrorCode
+	}{
+		{
+			name:       "take online node offline",
+			state:      `{"displayName": "agent-1"}`,
+			offline:    true,
+			wantAction: "/computer/agent-1/toggleOffline?offlineMessage=disk+cleanup",
+		},
+		{
+			name:       "update reason of offline node",
+			state:      `{"displayName": "agent-1", "offline": true, "temporarilyOffline": true}`,
+			offline:    true,
+			wantAction: "/computer/agent-1/changeOfflineCause?offlineMessage=disk+cleanup",
+		},
+		{
+			name:       "bring offline node online",
+			state:      `{"displayName": "agent-1", "offline": true, "temporarilyOffline": true}`,
+			offline:    false,
+			wantAction: "/computer/agent-1/toggleOffline",
+		},
+		{
+			name:    "online node stays online",
+			state:   `{"displayName": "agent-1"}`,
+			offline: false,
+		},
+		{
+			name:    "disconnected node cannot be brought online",
+			state:   `{"displayName": "agent-1", "offline": true}`,
+			offline: false,
+			wantErr: ErrorCodeInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var action string
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/computer/agent-1/api/json":
+					w.Write([]byte(tt.state))
+				case r.Method == http.MethodPost:
+					action = r.URL.RequestURI()
+					w.WriteHeader(http.StatusFound)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			err := client.SetNodeOffline(context.Background(), "agent-1", tt.offline, "disk cleanup")
+			if tt.wantErr != "" {
+				if !IsErrorCode(err, tt.wantErr) {
+					t.Fatalf("SetNodeOffline() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetNodeOffline() error = %v", err)
+			}
+			if action != tt.wantAction {
+				t.Errorf("POST %q, want %q", action, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestSetNodeOfflineReadsCurrentState(t *testing.T) {
+	state := `{"displayName": "agent-1"}`
+	var action string
+	client := newCachedTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/crumbIssuer/api/json":
+			http.NotFound(w, r)
+		case r.Method == http.MethodGet && r.URL.Path == "/computer/agent-1/api/json":
+			w.Write([]byte(state))
+		case r.Method == http.MethodPost:
+			action = r.URL.Path
+			w.WriteHeader(http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}), config.CacheConfig{TTLs: config.DefaultCacheTTLs})
+	ctx := context.Background()
+
+	// The cached node is online, but someone else took it offline since
+	if _, err := client.GetNode(ctx, "agent-1"); err != nil {
+		t.Fatalf("GetNode() error = %v", err)
+	}
+	state = `{"displayName": "agent-1", "offline": true, "temporarilyOffline": true}`
+
+	if err := client.SetNodeOffline(ctx, "agent-1", true, "disk cleanup"); err != nil {
+		t.Fatalf("SetNodeOffline() error = %v", err)
+	}
+	if action != "/computer/agent-1/changeOfflineCause" {
+		t.Errorf("POST %q, want the reason updated rather than the node toggled back online", action)
+	}
+}
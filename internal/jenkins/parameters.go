@@ -0,0 +1,211 @@
+package jenkins
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+)
+
+// Kinds of build parameter values
+const (
+	ParameterKindString      = "string"
+	ParameterKindText        = "text"
+	ParameterKindBoolean     = "boolean"
+	ParameterKindChoice      = "choice"
+	ParameterKindPassword    = "password"
+	ParameterKindFile        = "file"
+	ParameterKindRun         = "run"
+	ParameterKindCredentials = "credentials"
+	ParameterKindOther       = "other"
+)
+
+// Jenkins parameter definition types with special handling
+const (
+	PasswordParameterType = "PasswordParameterDefinition"
+	// FileParameterType is the built-in file parameter, uploaded as multipart form data
+	FileParameterType = "FileParameterDefinition"
+	// StashedFileParameterType is the file-parameters plugin's stashed file parameter, also uploaded as multipart form data
+	StashedFileParameterType = "StashedFileParameterDefinition"
+	// Base64FileParameterType is the file-parameters plugin's parameter that takes the file content as a base64 string
+	Base64FileParameterType = "Base64FileParameterDefinition"
+)
+
+// parameterKinds maps Jenkins parameter definition types to the kind of value they take
+var parameterKinds = map[string]string{
+	"StringParameterDefinition":      ParameterKindString,
+	"TextParameterDefinition":        ParameterKindText,
+	"BooleanParameterDefinition":     ParameterKindBoolean,
+	"ChoiceParameterDefinition":      ParameterKindChoice,
+	PasswordParameterType:            ParameterKindPassword,
+	FileParameterType:                ParameterKindFile,
+	StashedFileParameterType:         ParameterKindFile,
+	Base64FileParameterType:          ParameterKindFile,
+	"RunParameterDefinition":         ParameterKindRun,
+	"CredentialsParameterDefinition": ParameterKindCredentials,
+}
+
+// parameterKind returns the kind of value a Jenkins parameter definition type takes
+func parameterKind(parameterType string) string {
+	if kind, ok := parameterKinds[parameterType]; ok {
+		return kind
+	}
+	return ParameterKindOther
+}
+
+// validateParameters checks the given values and files against the job's
+// parameter definitions and returns the values to send: booleans are
+// normalized to "true" or "false" and files for base64 file parameters are
+// encoded as values. Files of the other file parameters are uploaded as
+// multipart form data and are not part of the returned values.
+func (c *Client) validateParameters(jobDetails *JobDetails, params map[string]string, files map[string]BuildFile) (map[string]string, error) {
+	// Build a map of valid parameter names from job definition
+	validParams := make(map[string]JobParameter)
+	for _, param := range jobDetails.Parameters {
+		validParams[param.Name] = param
+	}
+
+	values := make(map[string]string, len(params))
+	for paramName, value := range params {
+		param, exists := validParams[paramName]
+		if !exists {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s is not defined for this job", paramName))
+		}
+
+		switch param.Kind {
+		case ParameterKindBoolean:
+			b, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s must be true or false, got %q", paramName, value))
+			}
+			value = strconv.FormatBool(b)
+		case ParameterKindChoice:
+			if len(param.Choices) > 0 && !containsString(param.Choices, value) {
+				return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s must be one of [%s], got %q",
+					paramName, strings.Join(param.Choices, ", "), value))
+			}
+		case ParameterKindFile:
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s is a file parameter and must be passed as a file", paramName))
+		}
+
+		values[paramName] = value
+	}
+
+	for paramName, file := range files {
+		param, exists := validParams[paramName]
+		if !exists {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s is not defined for this job", paramName))
+		}
+		if param.Kind != ParameterKindFile {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s is a %s parameter, not a file parameter", paramName, param.Kind))
+		}
+		if _, ok := params[paramName]; ok {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid parameter: %s is given both as a value and as a file", paramName))
+		}
+
+		if param.Type == Base64FileParameterType {
+			values[paramName] = base64.StdEncoding.EncodeToString(file.Content)
+		}
+	}
+
+	return values, nil
+}
+
+// uploadedFiles returns the files that are uploaded as multipart form data,
+// i.e. all files except those of base64 file parameters. Files without a
+// name are named after their parameter.
+func uploadedFiles(jobDetails *JobDetails, files map[string]BuildFile) map[string]BuildFile {
+	uploads := make(map[string]BuildFile)
+	for _, param := range jobDetails.Parameters {
+		file, ok := files[param.Name]
+		if !ok || param.Type == Base64FileParameterType {
+			continue
+		}
+		if file.FileName == "" {
+			file.FileName = param.Name
+		}
+		uploads[param.Name] = file
+	}
+	return uploads
+}
+
+// multipartParameters encodes parameter values and files as a multipart form,
+// as accepted by Jenkins' buildWithParameters endpoint. It returns the body
+// and its content type.
+func multipartParameters(params map[string]string, files map[string]BuildFile) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for _, name := range sortedStringKeys(params) {
+		if err := w.WriteField(name, params[name]); err != nil {
+			return nil, "", err
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		file := files[name]
+		part, err := w.CreateFormFile(name, file.FileName)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, w.FormDataContentType(), nil
+}
+
+// RegisterSecretParameters registers the values given for the job's password
+// parameters as secrets, so that they are masked in logs and build output
+func RegisterSecretParameters(jobDetails *JobDetails, params map[string]string) {
+	for _, param := range jobDetails.Parameters {
+		if value, ok := params[param.Name]; ok && param.IsSecret() {
+			redact.AddSecret(value)
+		}
+	}
+}
+
+// maskSecretParameters returns a copy of params with the values of password
+// parameters and of parameters with sensitive names masked
+func maskSecretParameters(jobDetails *JobDetails, params map[string]string) map[string]string {
+	masked := redact.Parameters(params)
+	for _, param := range jobDetails.Parameters {
+		if _, ok := masked[param.Name]; ok && param.IsSecret() {
+			masked[param.Name] = redact.Mask
+		}
+	}
+	return masked
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
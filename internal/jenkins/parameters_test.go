@@ -0,0 +1,195 @@
+package jenkins
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// parameterJob is a job definition with one parameter of every kind
+const parameterJob = `{"name":"release","property":[{"parameterDefinitions":[
+	{"name":"VERSION","type":"StringParameterDefinition","defaultParameterValue":{"value":"1.0"}},
+	{"name":"NOTES","type":"TextParameterDefinition"},
+	{"name":"DRY_RUN","type":"BooleanParameterDefinition","defaultParameterValue":{"value":true}},
+	{"name":"ENV","type":"ChoiceParameterDefinition","choices":["dev","staging","prod"],"defaultParameterValue":{"value":"dev"}},
+	{"name":"SIGNING_KEY","type":"PasswordParameterDefinition"},
+	{"name":"MANIFEST","type":"FileParameterDefinition"},
+	{"name":"CONFIG","type":"Base64FileParameterDefinition"}]}]}`
+
+func TestGetJobParameterMetadata(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(parameterJob))
+	}))
+
+	job, err := client.GetJob(context.Background(), "release")
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+
+	wantKinds := []string{
+		ParameterKindString, ParameterKindText, ParameterKindBoolean, ParameterKindChoice,
+		ParameterKindPassword, ParameterKindFile, ParameterKindFile,
+	}
+	if len(job.Parameters) != len(wantKinds) {
+		t.Fatalf("GetJob() returned %d parameters, want %d", len(job.Parameters), len(wantKinds))
+	}
+	for i, kind := range wantKinds {
+		if job.Parameters[i].Kind != kind {
+			t.Errorf("%s kind = %q, want %q", job.Parameters[i].Name, job.Parameters[i].Kind, kind)
+		}
+	}
+	if want := []string{"dev", "staging", "prod"}; !reflect.DeepEqual(job.Parameters[3].Choices, want) {
+		t.Errorf("ENV choices = %v, want %v", job.Parameters[3].Choices, want)
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(parameterJob))
+	}))
+	job, err := client.GetJob(context.Background(), "release")
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]string
+		files   map[string]BuildFile
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "string and text values",
+			params: map[string]string{"VERSION": "2.0", "NOTES": "line 1\nline 2"},
+			want:   map[string]string{"VERSION": "2.0", "NOTES": "line 1\nline 2"},
+		},
+		{
+			name:   "boolean is normalized",
+			params: map[string]string{"DRY_RUN": "TRUE"},
+			want:   map[string]string{"DRY_RUN": "true"},
+		},
+		{
+			name:    "invalid boolean",
+			params:  map[string]string{"DRY_RUN": "maybe"},
+			wantErr: true,
+		},
+		{
+			name:   "valid choice",
+			params: map[string]string{"ENV": "staging"},
+			want:   map[string]string{"ENV": "staging"},
+		},
+		{
+			name:    "choice not in list",
+			params:  map[string]string{"ENV": "qa"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown parameter",
+			params:  map[string]string{"UNKNOWN": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "file parameter given as value",
+			params:  map[string]string{"MANIFEST": "manifest.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "file for a string parameter",
+			files:   map[string]BuildFile{"VERSION": {Content: []byte("2.0")}},
+			wantErr: true,
+		},
+		{
+			name:  "uploaded file is not a value",
+			files: map[string]BuildFile{"MANIFEST": {FileName: "manifest.yaml", Content: []byte("a: 1")}},
+			want:  map[string]string{},
+		},
+		{
+			name:  "base64 file is encoded as value",
+			files: map[string]BuildFile{"CONFIG": {Content: []byte("key=value")}},
+			want:  map[string]string{"CONFIG": "a2V5PXZhbHVl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.validateParameters(job, tt.params, tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if code, _ := GetErrorCode(err); code != ErrorCodeInvalidInput {
+					t.Errorf("validateParameters() error code = %s, want %s", code, ErrorCodeInvalidInput)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriggerBuildWithFiles(t *testing.T) {
+	var fields map[string][]string
+	var manifest []byte
+	var manifestName string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/release/api/json":
+			w.Write([]byte(parameterJob))
+		case "/job/release/buildWithParameters":
+			if r.URL.RawQuery != "" {
+				t.Errorf("parameters sent in query %q, want only the multipart form", r.URL.RawQuery)
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("ParseMultipartForm() error = %v", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fields = r.MultipartForm.Value
+			file, header, err := r.FormFile("MANIFEST")
+			if err != nil {
+				t.Errorf("FormFile() error = %v", err)
+			} else {
+				manifestName = header.Filename
+				manifest, _ = io.ReadAll(file)
+				file.Close()
+			}
+			w.Header().Set("Location", "http://jenkins/queue/item/9/")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	queueItem, err := client.TriggerBuildWithFiles(context.Background(), "release",
+		map[string]string{"ENV": "prod", "DRY_RUN": "0"},
+		map[string]BuildFile{
+			"MANIFEST": {FileName: "manifest.yaml", Content: []byte("replicas: 3")},
+			"CONFIG":   {Content: []byte("key=value")},
+		})
+	if err != nil {
+		t.Fatalf("TriggerBuildWithFiles() error = %v", err)
+	}
+
+	want := map[string][]string{"ENV": {"prod"}, "DRY_RUN": {"false"}, "CONFIG": {"a2V5PXZhbHVl"}}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("form fields = %v, want %v", fields, want)
+	}
+	if manifestName != "manifest.yaml" || string(manifest) != "replicas: 3" {
+		t.Errorf("uploaded file %q = %q, want manifest.yaml with its content", manifestName, manifest)
+	}
+
+	if queueItem.ID != 9 {
+		t.Errorf("queue item ID = %d, want 9", queueItem.ID)
+	}
+	if got := queueItem.Parameters["MANIFEST"]; got != "file manifest.yaml (11 bytes)" {
+		t.Errorf("queue item MANIFEST parameter = %q, want the file description", got)
+	}
+	if got := queueItem.Parameters["CONFIG"]; got != "file CONFIG (9 bytes)" {
+		t.Errorf("queue item CONFIG parameter = %q, want the file description", got)
+	}
+}
@@ -0,0 +1,112 @@
+package jenkins
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// folderClasses lists the Jenkins item classes that contain child jobs
+var folderClasses = map[string]bool{
+	"com.cloudbees.hudson.plugins.folder.Folder":                            true,
+	"jenkins.branch.OrganizationFolder":                                     true,
+	"org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject": true,
+}
+
+// jobPath converts a slash-separated job name into a Jenkins URL path.
+// For example "team/service/main" becomes "/job/team/job/service/job/main".
+// Each segment is escaped individually so names containing spaces or other
+// reserved characters are addressed correctly.
+func jobPath(jobName string) string {
+	var b strings.Builder
+	for _, segment := range strings.Split(jobName, "/") {
+		if segment == "" {
+			continue
+		}
+		b.WriteString("/job/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
+
+// escapePath escapes each segment of a slash-separated relative path while
+// keeping the separators intact
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// isFolder reports whether the given Jenkins item class can contain child jobs
+func isFolder(class string) bool {
+	return folderClasses[class]
+}
+
+// jobNameFromBuildURL extracts the slash-separated job full name and build number
+// from a build URL such as "https://jenkins/job/team/job/app/12/". It works for
+// controllers served under a context path. The build number is 0 when the URL
+// does not end in one.
+func jobNameFromBuildURL(buildURL string) (string, int) {
+	u, err := url.Parse(buildURL)
+	if err != nil {
+		return "", 0
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	var names []string
+	number := 0
+	for i := 0; i < len(segments); i++ {
+		if segments[i] == "job" && i+1 < len(segments) {
+			name, err := url.PathUnescape(segments[i+1])
+			if err != nil {
+				name = segments[i+1]
+			}
+			names = append(names, name)
+			i++
+			continue
+		}
+		if n, err := strconv.Atoi(segments[i]); err == nil && i == len(segments)-1 {
+			number = n
+		}
+	}
+
+	return strings.Join(names, "/"), number
+}
+
+// queueTaskJobName returns the full name of the job a queue item builds,
+// falling back to the task name when the task URL does not address a job
+func queueTaskJobName(name, taskURL string) string {
+	if jobName, _ := jobNameFromBuildURL(taskURL); jobName != "" {
+		return jobName
+	}
+	return name
+}
+
+// jobFromPath extracts the job full name and build number addressed by a
+// request path such as "/job/team/job/app/12/api/json". The job name is
+// empty when the path does not address a job, and the build number is 0
+// when it does not address a build.
+func jobFromPath(path string) (string, int) {
+	prefix := jobPathPrefix(path)
+	if prefix == "" {
+		return "", 0
+	}
+
+	segments := strings.Split(strings.TrimPrefix(prefix, "/"), "/")
+	names := make([]string, 0, len(segments)/2)
+	for i := 1; i < len(segments); i += 2 {
+		name, err := url.PathUnescape(segments[i])
+		if err != nil {
+			name = segments[i]
+		}
+		names = append(names, name)
+	}
+
+	p, _, _ := strings.Cut(path, "?")
+	rest := strings.TrimPrefix(strings.TrimPrefix(p, prefix), "/")
+	number, _ := strconv.Atoi(strings.SplitN(rest, "/", 2)[0])
+
+	return strings.Join(names, "/"), number
+}
@@ -0,0 +1,139 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// htmlTagPattern matches the markup wfapi adds to console log text
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// GetPipelineRun retrieves the stages of a pipeline build from /wfapi/describe
+func (c *Client) GetPipelineRun(ctx context.Context, jobName string, buildNumber int) (*PipelineRun, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+
+	path := fmt.Sprintf("%s/%d/wfapi/describe", jobPath(jobName), buildNumber)
+
+	var run PipelineRun
+	if err := c.getWorkflowJSON(ctx, path, jobName, buildNumber, &run); err != nil {
+		return nil, err
+	}
+
+	if run.Stages == nil {
+		run.Stages = []Stage{}
+	}
+	run.FailedStage = FirstFailedStage(run.Stages)
+
+	return &run, nil
+}
+
+// GetStageLog retrieves the console output of every step in a pipeline stage
+// using the per-node /execution/node/{id}/wfapi/log endpoint
+func (c *Client) GetStageLog(ctx context.Context, jobName string, buildNumber int, stageID string) (*StageLog, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+	if stageID == "" {
+		return nil, NewInvalidInputError("stage ID cannot be empty")
+	}
+
+	nodePath := fmt.Sprintf("%s/%d/execution/node/%s", jobPath(jobName), buildNumber, url.PathEscape(stageID))
+
+	// Describe the stage to find the flow nodes (steps) it contains
+	var stage struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Status         string `json:"status"`
+		StageFlowNodes []struct {
+			ID                   string      `json:"id"`
+			Name                 string      `json:"name"`
+			Status               string      `json:"status"`
+			ParameterDescription string      `json:"parameterDescription"`
+			Error                *StageError `json:"error"`
+		} `json:"stageFlowNodes"`
+	}
+	if err := c.getWorkflowJSON(ctx, nodePath+"/wfapi/describe", jobName, buildNumber, &stage); err != nil {
+		return nil, err
+	}
+
+	stageLog := &StageLog{
+		StageID:   stage.ID,
+		StageName: stage.Name,
+		Status:    stage.Status,
+		Steps:     make([]StepLog, 0, len(stage.StageFlowNodes)),
+	}
+
+	for _, node := range stage.StageFlowNodes {
+		var nodeLog struct {
+			Text    string `json:"text"`
+			HasMore bool   `json:"hasMore"`
+		}
+		logPath := fmt.Sprintf("%s/%d/execution/node/%s/wfapi/log", jobPath(jobName), buildNumber, url.PathEscape(node.ID))
+		if err := c.getWorkflowJSON(ctx, logPath, jobName, buildNumber, &nodeLog); err != nil {
+			return nil, err
+		}
+
+		stageLog.Steps = append(stageLog.Steps, StepLog{
+			NodeID:      node.ID,
+			Name:        node.Name,
+			Description: node.ParameterDescription,
+			Status:      node.Status,
+			Error:       node.Error,
+			Text:        html.UnescapeString(htmlTagPattern.ReplaceAllString(nodeLog.Text, "")),
+			HasMore:     nodeLog.HasMore,
+		})
+	}
+
+	return stageLog, nil
+}
+
+// FirstFailedStage returns the first stage with FAILED status, or nil if none failed
+func FirstFailedStage(stages []Stage) *Stage {
+	for i := range stages {
+		if stages[i].Status == StageStatusFailed {
+			return &stages[i]
+		}
+	}
+	return nil
+}
+
+// getWorkflowJSON fetches a Workflow API endpoint and decodes its JSON response into v
+func (c *Client) getWorkflowJSON(ctx context.Context, path, jobName string, buildNumber int, v interface{}) error {
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return NewHTTPError(resp, fmt.Sprintf("pipeline data of build %s #%d (is this a pipeline job with the Pipeline Stage View plugin installed?)", jobName, buildNumber))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	return nil
+}
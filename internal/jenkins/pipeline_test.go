@@ -0,0 +1,71 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetPipelineRun(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/app/12/wfapi/describe" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"id": "12", "name": "#12", "status": "FAILED", "durationMillis": 9000,
+			"stages": [
+				{"id": "6", "name": "Checkout", "status": "SUCCESS", "durationMillis": 1000},
+				{"id": "14", "name": "Test", "status": "FAILED", "durationMillis": 7000,
+				 "error": {"message": "script returned exit code 1", "type": "hudson.AbortException"}},
+				{"id": "30", "name": "Deploy", "status": "NOT_EXECUTED"}
+			]
+		}`))
+	}))
+
+	run, err := client.GetPipelineRun(context.Background(), "app", 12)
+	if err != nil {
+		t.Fatalf("GetPipelineRun() error = %v", err)
+	}
+	if len(run.Stages) != 3 {
+		t.Fatalf("len(Stages) = %d, want 3", len(run.Stages))
+	}
+	if run.FailedStage == nil || run.FailedStage.Name != "Test" {
+		t.Fatalf("FailedStage = %+v, want Test", run.FailedStage)
+	}
+	if run.FailedStage.Error == nil || run.FailedStage.Error.Message != "script returned exit code 1" {
+		t.Errorf("FailedStage.Error = %+v, want exit code message", run.FailedStage.Error)
+	}
+
+	if _, err := client.GetPipelineRun(context.Background(), "freestyle", 1); err == nil {
+		t.Error("GetPipelineRun() expected error for non-pipeline job")
+	}
+}
+
+func TestGetStageLog(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/12/execution/node/14/wfapi/describe":
+			w.Write([]byte(`{"id": "14", "name": "Test", "status": "FAILED",
+				"stageFlowNodes": [
+					{"id": "15", "name": "Shell Script", "status": "FAILED", "parameterDescription": "make test"}
+				]}`))
+		case "/job/app/12/execution/node/15/wfapi/log":
+			w.Write([]byte(`{"nodeId": "15", "hasMore": false,
+				"text": "<span class=\"timestamp\">10:00</span> FAIL: TestLogin &amp; cleanup\n"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	stageLog, err := client.GetStageLog(context.Background(), "app", 12, "14")
+	if err != nil {
+		t.Fatalf("GetStageLog() error = %v", err)
+	}
+	if stageLog.StageName != "Test" || len(stageLog.Steps) != 1 {
+		t.Fatalf("GetStageLog() = %+v, want stage Test with one step", stageLog)
+	}
+	if want := "10:00 FAIL: TestLogin & cleanup\n"; stageLog.Steps[0].Text != want {
+		t.Errorf("step text = %q, want %q", stageLog.Steps[0].Text, want)
+	}
+}
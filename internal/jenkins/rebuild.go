@@ -0,0 +1,337 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+)
+
+// passwordParameterValueClass is the class of password values in a build's parameters action
+const passwordParameterValueClass = "hudson.model.PasswordParameterValue"
+
+// replayFieldPattern matches the script text areas of a build's replay page.
+// The main script is named "_.mainScript"; loaded scripts are named after their
+// class, with dots replaced by underscores.
+var replayFieldPattern = regexp.MustCompile(`(?s)<textarea[^>]*\bname="_\.([^"]+)"[^>]*>(.*?)</textarea>`)
+
+// replayMainScriptField is the replay form field holding the main pipeline script
+const replayMainScriptField = "mainScript"
+
+// GetBuildInputs retrieves the parameter values and causes a build was started
+// with. Parameters whose values Jenkins does not report, such as passwords and
+// uploaded files, are listed as unreported.
+func (c *Client) GetBuildInputs(ctx context.Context, jobName string, buildNumber int) (*BuildInputs, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+
+	path := fmt.Sprintf("%s/%d/api/json", jobPath(jobName), buildNumber)
+	path += "?tree=number,url,building,actions[" + causeTree + ",parameters[name,value]]"
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("build %s #%d", jobName, buildNumber))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	var rawBuild struct {
+		Number  int    `json:"number"`
+		URL     string `json:"url"`
+		Actions []struct {
+			Causes     []rawCause `json:"causes"`
+			Parameters []struct {
+				Class string      `json:"_class"`
+				Name  string      `json:"name"`
+				Value interface{} `json:"value"`
+			} `json:"parameters"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal(body, &rawBuild); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	inputs := &BuildInputs{
+		Number:     rawBuild.Number,
+		URL:        rawBuild.URL,
+		Parameters: make(map[string]string),
+	}
+	for _, action := range rawBuild.Actions {
+		inputs.Causes = append(inputs.Causes, buildCauses(action.Causes)...)
+		for _, param := range action.Parameters {
+			if param.Value == nil || param.Class == passwordParameterValueClass {
+				inputs.Unreported = append(inputs.Unreported, param.Name)
+				continue
+			}
+			inputs.Parameters[param.Name] = parameterValueString(param.Value)
+		}
+	}
+
+	return inputs, nil
+}
+
+// RebuildBuild triggers a new build of a job with the parameter values of an
+// earlier build, replaced by the given overrides. Parameters the job no longer
+// defines are dropped; password and file parameters, whose values Jenkins does
+// not report, fall back to the job defaults unless overridden.
+func (c *Client) RebuildBuild(ctx context.Context, jobName string, buildNumber int, overrides map[string]string) (*Rebuild, error) {
+	// The resubmitted values must not come from a cached copy of the build or job
+	inputs, err := c.GetBuildInputs(withoutCache(ctx), jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	jobDetails, err := c.GetJob(withoutCache(ctx), jobName)
+	if err != nil {
+		return nil, err
+	}
+	definitions := make(map[string]JobParameter, len(jobDetails.Parameters))
+	for _, param := range jobDetails.Parameters {
+		definitions[param.Name] = param
+	}
+
+	rebuild := &Rebuild{
+		SourceBuild:  inputs.Number,
+		SourceCauses: inputs.Causes,
+	}
+
+	params := make(map[string]string, len(inputs.Parameters)+len(overrides))
+	for name, value := range inputs.Parameters {
+		param, ok := definitions[name]
+		switch {
+		case !ok:
+			rebuild.Dropped = append(rebuild.Dropped, name)
+		case param.IsSecret() || param.Kind == ParameterKindFile:
+			inputs.Unreported = append(inputs.Unreported, name)
+		default:
+			params[name] = value
+		}
+	}
+	for _, name := range inputs.Unreported {
+		if _, ok := overrides[name]; ok {
+			continue
+		}
+		if _, ok := definitions[name]; ok {
+			rebuild.Defaulted = append(rebuild.Defaulted, name)
+		} else {
+			rebuild.Dropped = append(rebuild.Dropped, name)
+		}
+	}
+	sort.Strings(rebuild.Defaulted)
+	sort.Strings(rebuild.Dropped)
+
+	// Overrides are validated together with the reused values
+	for name, value := range overrides {
+		params[name] = value
+	}
+
+	rebuild.QueueItem, err = c.triggerBuild(ctx, jobDetails, jobName, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rebuild, nil
+}
+
+// GetReplayScripts retrieves the pipeline scripts a build ran with from its
+// replay page. Replaying requires the Replay permission on the job.
+func (c *Client) GetReplayScripts(ctx context.Context, jobName string, buildNumber int) (*ReplayScripts, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+
+	path := fmt.Sprintf("%s/%d/replay/", jobPath(jobName), buildNumber)
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("replay of build %s #%d (is this a pipeline build you may replay?)", jobName, buildNumber))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	scripts := &ReplayScripts{}
+	found := false
+	for _, match := range replayFieldPattern.FindAllStringSubmatch(string(body), -1) {
+		// A newline directly after the opening tag is not part of the value
+		text := html.UnescapeString(strings.TrimPrefix(strings.TrimPrefix(match[2], "\r"), "\n"))
+		if match[1] == replayMainScriptField {
+			scripts.MainScript = text
+			found = true
+			continue
+		}
+		if scripts.LoadedScripts == nil {
+			scripts.LoadedScripts = make(map[string]string)
+		}
+		scripts.LoadedScripts[match[1]] = text
+	}
+	if !found {
+		return nil, NewJenkinsError(fmt.Sprintf("replay page of build %s #%d has no main script", jobName, buildNumber))
+	}
+
+	return scripts, nil
+}
+
+// ReplayBuild replays a pipeline build with a modified main script and loaded
+// scripts. An empty main script and loaded scripts that are not given keep the
+// text the build ran with. Secrets masked in scripts edited from a redacted
+// copy are restored from the scripts the build ran with. The replayed build
+// reuses the parameters of the original build. Jenkins does not report the
+// queue item of a replay, so the number the new build is expected to get is
+// returned instead.
+func (c *Client) ReplayBuild(ctx context.Context, jobName string, buildNumber int, mainScript string, loadedScripts map[string]string) (*Rebuild, error) {
+	scripts, err := c.GetReplayScripts(ctx, jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{replayMainScriptField: scripts.MainScript}
+	if mainScript != "" {
+		text, err := unmaskScript(mainScript, scripts.MainScript, "main script")
+		if err != nil {
+			return nil, err
+		}
+		form[replayMainScriptField] = text
+	}
+	for name, text := range scripts.LoadedScripts {
+		form[name] = text
+	}
+	for name, text := range loadedScripts {
+		original, ok := scripts.LoadedScripts[name]
+		if !ok {
+			return nil, NewInvalidInputError(fmt.Sprintf("build %s #%d did not load a script named %s (loaded scripts: [%s])",
+				jobName, buildNumber, name, strings.Join(sortedStringKeys(scripts.LoadedScripts), ", ")))
+		}
+		text, err := unmaskScript(text, original, "loaded script "+name)
+		if err != nil {
+			return nil, err
+		}
+		form[name] = text
+	}
+
+	inputs, err := c.GetBuildInputs(withoutCache(ctx), jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	nextBuildNumber, err := c.getNextBuildNumber(ctx, jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replay form is submitted as JSON in the "json" form field
+	data, err := json.Marshal(form)
+	if err != nil {
+		return nil, WrapError(ErrorCodeInternalError, "failed to encode replay form", err)
+	}
+	body := url.Values{"json": {string(data)}}.Encode()
+
+	path := fmt.Sprintf("%s/%d/replay/run", jobPath(jobName), buildNumber)
+	if err := c.postJobAction(ctx, path, strings.NewReader(body), "application/x-www-form-urlencoded",
+		fmt.Sprintf("replay of build %s #%d", jobName, buildNumber)); err != nil {
+		return nil, err
+	}
+
+	return &Rebuild{
+		SourceBuild:     inputs.Number,
+		SourceCauses:    inputs.Causes,
+		Replayed:        true,
+		NextBuildNumber: nextBuildNumber,
+	}, nil
+}
+
+// unmaskScript restores the secrets masked in a script edited from its
+// redacted original. Lines still containing the redaction mask are replaced
+// by the original line they were masked from; a masked line that matches no
+// original line, or several different ones, cannot be restored and is rejected
+// rather than sent to Jenkins with the mask in place.
+func unmaskScript(text, original, what string) (string, error) {
+	if !strings.Contains(text, redact.Mask) {
+		return text, nil
+	}
+
+	originals := make(map[string]string)
+	ambiguous := make(map[string]bool)
+	for _, line := range strings.Split(original, "\n") {
+		masked := redact.String(line)
+		if masked == line {
+			continue
+		}
+		if previous, ok := originals[masked]; ok && previous != line {
+			ambiguous[masked] = true
+		}
+		originals[masked] = line
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, redact.Mask) {
+			continue
+		}
+		restored, ok := originals[line]
+		if !ok || ambiguous[line] {
+			return "", NewInvalidInputError(fmt.Sprintf("line %d of the %s contains the redaction mask %q and does not match a line of the original script; "+
+				"write out the value or leave the line unchanged", i+1, what, redact.Mask))
+		}
+		lines[i] = restored
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// getNextBuildNumber returns the number the next build of a job will get
+func (c *Client) getNextBuildNumber(ctx context.Context, jobName string) (int, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, jobPath(jobName)+"/api/json?tree=nextBuildNumber", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, NewHTTPError(resp, fmt.Sprintf("job %s", jobName))
+	}
+
+	var job struct {
+		NextBuildNumber int `json:"nextBuildNumber"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return 0, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	return job.NextBuildNumber, nil
+}
@@ -0,0 +1,228 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+)
+
+// deployBuild is a build of a parameterized deploy job started by a user
+const deployBuild = `{"number":41,"url":"http://jenkins/job/deploy/41/","actions":[
+	{"_class":"hudson.model.CauseAction","causes":[{"shortDescription":"Started by user Alice","userId":"alice","userName":"Alice"}]},
+	{"_class":"hudson.model.ParametersAction","parameters":[
+		{"_class":"hudson.model.StringParameterValue","name":"VERSION","value":"1.4.2"},
+		{"_class":"hudson.model.BooleanParameterValue","name":"DRY_RUN","value":false},
+		{"_class":"hudson.model.StringParameterValue","name":"ENV","value":"staging"},
+		{"_class":"hudson.model.PasswordParameterValue","name":"SIGNING_KEY"},
+		{"_class":"hudson.model.StringParameterValue","name":"REMOVED","value":"x"}]},
+	{}]}`
+
+// deployJob defines the parameters of the deploy job; REMOVED is no longer one of them
+const deployJob = `{"name":"deploy","property":[{"parameterDefinitions":[
+	{"name":"VERSION","type":"StringParameterDefinition"},
+	{"name":"DRY_RUN","type":"BooleanParameterDefinition","defaultParameterValue":{"value":true}},
+	{"name":"ENV","type":"ChoiceParameterDefinition","choices":["staging","prod"]},
+	{"name":"SIGNING_KEY","type":"PasswordParameterDefinition","defaultParameterValue":{"value":""}}]}]}`
+
+func TestGetBuildInputs(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/deploy/41/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(deployBuild))
+	}))
+
+	inputs, err := client.GetBuildInputs(context.Background(), "deploy", 41)
+	if err != nil {
+		t.Fatalf("GetBuildInputs() error = %v", err)
+	}
+
+	wantParams := map[string]string{"VERSION": "1.4.2", "DRY_RUN": "false", "ENV": "staging", "REMOVED": "x"}
+	if !reflect.DeepEqual(inputs.Parameters, wantParams) {
+		t.Errorf("parameters = %v, want %v", inputs.Parameters, wantParams)
+	}
+	if !reflect.DeepEqual(inputs.Unreported, []string{"SIGNING_KEY"}) {
+		t.Errorf("unreported = %v, want [SIGNING_KEY]", inputs.Unreported)
+	}
+	if len(inputs.Causes) != 1 || inputs.Causes[0].UserID != "alice" {
+		t.Errorf("causes = %+v, want the user cause", inputs.Causes)
+	}
+}
+
+func TestRebuildBuild(t *testing.T) {
+	var query map[string][]string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/deploy/41/api/json":
+			w.Write([]byte(deployBuild))
+		case "/job/deploy/api/json":
+			w.Write([]byte(deployJob))
+		case "/job/deploy/buildWithParameters":
+			query = r.URL.Query()
+			w.Header().Set("Location", "http://jenkins/queue/item/88/")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	rebuild, err := client.RebuildBuild(context.Background(), "deploy", 41, map[string]string{"ENV": "prod"})
+	if err != nil {
+		t.Fatalf("RebuildBuild() error = %v", err)
+	}
+
+	wantQuery := map[string][]string{"VERSION": {"1.4.2"}, "DRY_RUN": {"false"}, "ENV": {"prod"}}
+	if !reflect.DeepEqual(query, wantQuery) {
+		t.Errorf("triggered with %v, want %v", query, wantQuery)
+	}
+	if rebuild.QueueItem == nil || rebuild.QueueItem.ID != 88 {
+		t.Errorf("queue item = %+v, want ID 88", rebuild.QueueItem)
+	}
+	if rebuild.SourceBuild != 41 || rebuild.Replayed {
+		t.Errorf("rebuild = %+v, want a re-trigger of build 41", rebuild)
+	}
+	if !reflect.DeepEqual(rebuild.Defaulted, []string{"SIGNING_KEY"}) {
+		t.Errorf("defaulted = %v, want [SIGNING_KEY]", rebuild.Defaulted)
+	}
+	if !reflect.DeepEqual(rebuild.Dropped, []string{"REMOVED"}) {
+		t.Errorf("dropped = %v, want [REMOVED]", rebuild.Dropped)
+	}
+
+	// Overrides are validated like any other parameter value
+	_, err = client.RebuildBuild(context.Background(), "deploy", 41, map[string]string{"ENV": "qa"})
+	if code, _ := GetErrorCode(err); code != ErrorCodeInvalidInput {
+		t.Errorf("RebuildBuild() with an invalid choice error = %v, want %s", err, ErrorCodeInvalidInput)
+	}
+}
+
+// replayPage is an excerpt of the replay page of a pipeline build that loaded one script
+const replayPage = `<html><body><form action="run" method="post" name="config">
+<textarea name="_.mainScript" class="workflow-editor">
+node {
+  def lib = load &#39;lib.groovy&#39;
+  sh &quot;make &amp;&amp; make test&quot;
+}</textarea>
+<textarea rows="20" name="_.Script1" class="workflow-editor">
+return this</textarea>
+</form></body></html>`
+
+func TestGetReplayScripts(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/app/7/replay/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(replayPage))
+	}))
+
+	scripts, err := client.GetReplayScripts(context.Background(), "app", 7)
+	if err != nil {
+		t.Fatalf("GetReplayScripts() error = %v", err)
+	}
+
+	wantMain := "node {\n  def lib = load 'lib.groovy'\n  sh \"make && make test\"\n}"
+	if scripts.MainScript != wantMain {
+		t.Errorf("main script = %q, want %q", scripts.MainScript, wantMain)
+	}
+	if want := map[string]string{"Script1": "return this"}; !reflect.DeepEqual(scripts.LoadedScripts, want) {
+		t.Errorf("loaded scripts = %v, want %v", scripts.LoadedScripts, want)
+	}
+
+	if _, err := client.GetReplayScripts(context.Background(), "freestyle", 1); err == nil {
+		t.Error("GetReplayScripts() succeeded for a build without a replay page")
+	}
+}
+
+func TestReplayBuild(t *testing.T) {
+	var form map[string]string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/7/replay/":
+			w.Write([]byte(replayPage))
+		case "/job/app/7/api/json":
+			w.Write([]byte(`{"number":7,"actions":[{"causes":[{"shortDescription":"Branch indexing"}]}]}`))
+		case "/job/app/api/json":
+			w.Write([]byte(`{"nextBuildNumber":12}`))
+		case "/job/app/7/replay/run":
+			if r.Method != http.MethodPost {
+				t.Errorf("replay method = %s, want POST", r.Method)
+			}
+			if err := json.Unmarshal([]byte(r.PostFormValue("json")), &form); err != nil {
+				t.Errorf("replay form json: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	rebuild, err := client.ReplayBuild(context.Background(), "app", 7, "node { sh 'make' }", nil)
+	if err != nil {
+		t.Fatalf("ReplayBuild() error = %v", err)
+	}
+
+	want := map[string]string{"mainScript": "node { sh 'make' }", "Script1": "return this"}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("replay form = %v, want %v", form, want)
+	}
+	if !rebuild.Replayed || rebuild.NextBuildNumber != 12 || rebuild.SourceBuild != 7 {
+		t.Errorf("rebuild = %+v, want a replay of build 7 expected as #12", rebuild)
+	}
+	if len(rebuild.SourceCauses) != 1 || rebuild.SourceCauses[0].ShortDescription != "Branch indexing" {
+		t.Errorf("source causes = %+v, want the branch indexing cause", rebuild.SourceCauses)
+	}
+
+	_, err = client.ReplayBuild(context.Background(), "app", 7, "", map[string]string{"Script9": "x"})
+	if code, _ := GetErrorCode(err); code != ErrorCodeInvalidInput {
+		t.Errorf("ReplayBuild() with an unknown loaded script error = %v, want %s", err, ErrorCodeInvalidInput)
+	}
+}
+
+func TestReplayBuildRestoresMaskedSecrets(t *testing.T) {
+	const page = `<textarea name="_.mainScript">node {
+  sh &quot;deploy --password=hunter2&quot;
+}</textarea>`
+
+	var form map[string]string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/7/replay/":
+			w.Write([]byte(page))
+		case "/job/app/7/api/json":
+			w.Write([]byte(`{"number":7}`))
+		case "/job/app/api/json":
+			w.Write([]byte(`{"nextBuildNumber":8}`))
+		case "/job/app/7/replay/run":
+			form = nil
+			json.Unmarshal([]byte(r.PostFormValue("json")), &form)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	// The edited script keeps the masked line and adds a step
+	edited := "node {\n  sh \"deploy --password=" + redact.Mask + "\"\n  sh 'notify'\n}"
+	if _, err := client.ReplayBuild(context.Background(), "app", 7, edited, nil); err != nil {
+		t.Fatalf("ReplayBuild() error = %v", err)
+	}
+	want := "node {\n  sh \"deploy --password=hunter2\"\n  sh 'notify'\n}"
+	if form["mainScript"] != want {
+		t.Errorf("replayed main script = %q, want %q", form["mainScript"], want)
+	}
+
+	// A masked line that was changed cannot be restored
+	form = nil
+	changed := "node {\n  sh \"deploy --verbose --password=" + redact.Mask + "\"\n}"
+	_, err := client.ReplayBuild(context.Background(), "app", 7, changed, nil)
+	if code, _ := GetErrorCode(err); code != ErrorCodeInvalidInput {
+		t.Errorf("ReplayBuild() with a changed masked line error = %v, want %s", err, ErrorCodeInvalidInput)
+	}
+	if form != nil {
+		t.Errorf("replay submitted %v, want no replay", form)
+	}
+}
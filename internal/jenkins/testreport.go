@@ -0,0 +1,148 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Test report filters accepted by TestReport.Summarize
+const (
+	TestFilterFailed    = "failed"    // FAILED and REGRESSION cases
+	TestFilterRegressed = "regressed" // REGRESSION cases only
+)
+
+// maxStackTraceLines bounds the stack trace kept per test case in summaries
+const maxStackTraceLines = 30
+
+// testReportTree selects the test report fields; stdout/stderr are omitted as they can be huge
+const testReportTree = "duration,failCount,passCount,skipCount," +
+	"suites[name,duration,cases[className,name,status,duration,errorDetails,errorStackTrace,age,failedSince]]"
+
+// GetTestReport retrieves the JUnit test report of a build from /testReport/api/json.
+// Aggregated reports (matrix and multi-module builds) are flattened into a single report.
+func (c *Client) GetTestReport(ctx context.Context, jobName string, buildNumber int) (*TestReport, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if buildNumber <= 0 {
+		return nil, NewInvalidInputError("build number must be positive")
+	}
+
+	path := fmt.Sprintf("%s/%d/testReport/api/json", jobPath(jobName), buildNumber)
+	path += "?tree=" + testReportTree + ",childReports[result[" + testReportTree + "]]"
+
+	// Make GET request
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Handle HTTP errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewHTTPError(resp, fmt.Sprintf("test report of build %s #%d (the build may not have published JUnit results)", jobName, buildNumber))
+	}
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to read response body", err)
+	}
+
+	var rawResult struct {
+		TestReport
+		ChildReports []struct {
+			Result TestReport `json:"result"`
+		} `json:"childReports"`
+	}
+
+	if err := json.Unmarshal(body, &rawResult); err != nil {
+		return nil, WrapError(ErrorCodeJenkinsError, "failed to parse response", err)
+	}
+
+	report := rawResult.TestReport
+	for _, child := range rawResult.ChildReports {
+		report.Duration += child.Result.Duration
+		report.FailCount += child.Result.FailCount
+		report.PassCount += child.Result.PassCount
+		report.SkipCount += child.Result.SkipCount
+		report.Suites = append(report.Suites, child.Result.Suites...)
+	}
+	if report.Suites == nil {
+		report.Suites = []TestSuite{}
+	}
+
+	return &report, nil
+}
+
+// FilterCases returns the test cases matching filter across all suites.
+// An empty filter or TestFilterFailed matches FAILED and REGRESSION cases.
+func (r *TestReport) FilterCases(filter string) []TestCase {
+	var cases []TestCase
+	for _, suite := range r.Suites {
+		for _, tc := range suite.Cases {
+			if matchesTestFilter(tc.Status, filter) {
+				cases = append(cases, tc)
+			}
+		}
+	}
+	return cases
+}
+
+// Summarize builds a compact summary with at most limit failing tests.
+// Regressions are listed first since they are the most likely cause of a new failure.
+func (r *TestReport) Summarize(filter string, limit int) *TestReportSummary {
+	cases := r.FilterCases(filter)
+	sort.SliceStable(cases, func(i, j int) bool {
+		return cases[i].Status == TestStatusRegression && cases[j].Status != TestStatusRegression
+	})
+
+	summary := &TestReportSummary{
+		Total:     r.PassCount + r.FailCount + r.SkipCount,
+		PassCount: r.PassCount,
+		FailCount: r.FailCount,
+		SkipCount: r.SkipCount,
+		Duration:  r.Duration,
+	}
+
+	if limit > 0 && len(cases) > limit {
+		cases = cases[:limit]
+		summary.Truncated = true
+	}
+	for i := range cases {
+		cases[i].ErrorStackTrace = truncateLines(cases[i].ErrorStackTrace, maxStackTraceLines)
+	}
+	if cases == nil {
+		cases = []TestCase{}
+	}
+	summary.FailedTests = cases
+
+	return summary
+}
+
+// matchesTestFilter reports whether a test case status matches the given filter
+func matchesTestFilter(status, filter string) bool {
+	switch filter {
+	case TestFilterRegressed:
+		return status == TestStatusRegression
+	default:
+		return status == TestStatusFailed || status == TestStatusRegression
+	}
+}
+
+// truncateLines keeps at most n lines of s, noting how many were dropped
+func truncateLines(s string, n int) string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "") + fmt.Sprintf("... (%d more lines)", len(lines)-n)
+}
@@ -0,0 +1,79 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetTestReport(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/app/5/testReport/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"childReports": [
+				{"result": {"failCount": 1, "passCount": 10, "skipCount": 0, "duration": 1.5,
+				 "suites": [{"name": "api", "cases": [
+					{"className": "api.UserTest", "name": "testCreate", "status": "PASSED"},
+					{"className": "api.UserTest", "name": "testDelete", "status": "FAILED", "age": 3, "failedSince": 2,
+					 "errorDetails": "expected 204"}]}]}},
+				{"result": {"failCount": 1, "passCount": 5, "skipCount": 1, "duration": 2.5,
+				 "suites": [{"name": "web", "cases": [
+					{"className": "web.LoginTest", "name": "testLogin", "status": "REGRESSION", "age": 1, "failedSince": 5,
+					 "errorDetails": "timeout"}]}]}}
+			]
+		}`))
+	}))
+
+	report, err := client.GetTestReport(context.Background(), "app", 5)
+	if err != nil {
+		t.Fatalf("GetTestReport() error = %v", err)
+	}
+	if report.FailCount != 2 || report.PassCount != 15 || report.SkipCount != 1 {
+		t.Errorf("counts = %d/%d/%d, want 2/15/1", report.FailCount, report.PassCount, report.SkipCount)
+	}
+	if len(report.Suites) != 2 {
+		t.Fatalf("len(Suites) = %d, want 2", len(report.Suites))
+	}
+
+	summary := report.Summarize(TestFilterFailed, 10)
+	if summary.Total != 18 || len(summary.FailedTests) != 2 {
+		t.Fatalf("Summarize() total = %d, failed = %d, want 18, 2", summary.Total, len(summary.FailedTests))
+	}
+	if summary.FailedTests[0].Name != "testLogin" {
+		t.Errorf("first failed test = %s, want regression testLogin first", summary.FailedTests[0].Name)
+	}
+
+	regressed := report.Summarize(TestFilterRegressed, 10)
+	if len(regressed.FailedTests) != 1 || regressed.FailedTests[0].Status != TestStatusRegression {
+		t.Errorf("Summarize(regressed) = %+v, want only the regression", regressed.FailedTests)
+	}
+
+	limited := report.Summarize(TestFilterFailed, 1)
+	if len(limited.FailedTests) != 1 || !limited.Truncated {
+		t.Errorf("Summarize(limit=1) returned %d tests, truncated=%v", len(limited.FailedTests), limited.Truncated)
+	}
+}
+
+func TestSummarizeTruncatesStackTraces(t *testing.T) {
+	report := &TestReport{
+		FailCount: 1,
+		Suites: []TestSuite{{Cases: []TestCase{{
+			Name:            "testDeep",
+			Status:          TestStatusFailed,
+			ErrorStackTrace: strings.Repeat("\tat com.example.Foo.bar(Foo.java:1)\n", 100),
+		}}}},
+	}
+
+	summary := report.Summarize("", 10)
+	trace := summary.FailedTests[0].ErrorStackTrace
+	if got := strings.Count(trace, "\n"); got != maxStackTraceLines {
+		t.Errorf("stack trace has %d lines, want %d", got, maxStackTraceLines)
+	}
+	if !strings.HasSuffix(trace, "(70 more lines)") {
+		t.Errorf("stack trace should note dropped lines, got suffix %q", trace[len(trace)-20:])
+	}
+}
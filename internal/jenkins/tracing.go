@@ -0,0 +1,48 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startRequestSpan starts the span of a Jenkins API request. It covers the
+// crumb fetch, cache lookup and every retry attempt of the request, and is
+// annotated with the job and build the request path addresses.
+func (c *Client) startRequestSpan(ctx context.Context, method, path, endpoint string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		tracing.InstanceKey.String(c.instance),
+		tracing.EndpointKey.String(endpoint),
+		semconv.HTTPRequestMethodKey.String(method),
+	}
+	jobName, buildNumber := jobFromPath(path)
+	if jobName != "" {
+		attrs = append(attrs, tracing.JobNameKey.String(jobName))
+	}
+	if buildNumber > 0 {
+		attrs = append(attrs, tracing.BuildNumberKey.Int(buildNumber))
+	}
+
+	return tracing.Tracer().Start(ctx, "jenkins "+method+" "+endpoint, trace.WithAttributes(attrs...))
+}
+
+// recordSpanError marks the span as failed with the error's ErrorCode
+func recordSpanError(span trace.Span, err error) {
+	code, _ := GetErrorCode(err)
+	tracing.RecordError(span, err, string(code))
+}
+
+// recordSpanResponse records the status code of a Jenkins response, marking
+// the span as failed for error statuses
+func recordSpanResponse(span trace.Span, resp *http.Response) {
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+		span.SetAttributes(tracing.ErrorCodeKey.String(string(statusErrorCode(resp.StatusCode))))
+	}
+}
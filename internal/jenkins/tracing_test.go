@@ -0,0 +1,180 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider that records finished spans for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return recorder
+}
+
+// spanAttributes returns the attributes of a span as a map
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestRequestSpans(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var mu sync.Mutex
+	attempts := 0
+	var traceparent string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		traceparent = r.Header.Get("traceparent")
+		if attempts++; attempts < 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"number":12,"building":false,"result":"SUCCESS"}`))
+	}))
+	client.httpClient.Transport.(*retryTransport).maxRetries = 1
+	client.httpClient.Transport.(*retryTransport).backoff = time.Millisecond
+
+	if _, err := client.GetBuild(context.Background(), "team/app", 12); err != nil {
+		t.Fatalf("GetBuild() failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 2 attempts and 1 request", len(spans))
+	}
+
+	request := spans[2]
+	if request.Name() != "jenkins GET build" {
+		t.Errorf("request span name = %q, want %q", request.Name(), "jenkins GET build")
+	}
+	attrs := spanAttributes(request)
+	if attrs[tracing.JobNameKey].AsString() != "team/app" || attrs[tracing.BuildNumberKey].AsInt64() != 12 {
+		t.Errorf("request span job attributes = %v, want team/app #12", attrs)
+	}
+	if attrs["http.response.status_code"].AsInt64() != http.StatusOK {
+		t.Errorf("request span status code = %v, want 200", attrs["http.response.status_code"])
+	}
+
+	for i, attempt := range spans[:2] {
+		if attempt.Parent().SpanID() != request.SpanContext().SpanID() {
+			t.Errorf("attempt %d is not a child of the request span", i)
+		}
+	}
+	if spans[0].Status().Code != codes.Error || spanAttributes(spans[0])[tracing.ErrorCodeKey].AsString() != string(ErrorCodeJenkinsError) {
+		t.Errorf("first attempt status = %v, attributes = %v, want a JENKINS_ERROR", spans[0].Status(), spanAttributes(spans[0]))
+	}
+	if resend := spanAttributes(spans[1])["http.request.resend_count"]; resend.AsInt64() != 1 {
+		t.Errorf("second attempt resend count = %v, want 1", resend)
+	}
+
+	if traceparent == "" {
+		t.Error("Jenkins did not receive a traceparent header")
+	}
+}
+
+func TestCrumbSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == crumbIssuerPath {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	if err := client.CancelQueueItem(context.Background(), 7); err != nil {
+		t.Fatalf("CancelQueueItem() failed: %v", err)
+	}
+
+	spansByName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spansByName[span.Name()] = span
+	}
+
+	request, ok := spansByName["jenkins POST cancelItem"]
+	if !ok {
+		t.Fatalf("no request span recorded: %v", spansByName)
+	}
+	crumb, ok := spansByName["jenkins GET crumb"]
+	if !ok {
+		t.Fatalf("no crumb span recorded: %v", spansByName)
+	}
+	if crumb.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Error("crumb span is not a child of the request span")
+	}
+	if crumb.Status().Code != codes.Error || spanAttributes(crumb)[tracing.ErrorCodeKey].AsString() != string(ErrorCodePermissionDenied) {
+		t.Errorf("crumb span status = %v, want a PERMISSION_DENIED error", crumb.Status())
+	}
+}
+
+func TestRequestSpanNetworkError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	client, err := NewClient(&config.Config{
+		JenkinsURL: "http://127.0.0.1:1",
+		Username:   "admin",
+		Password:   "password",
+		Timeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	if _, err := client.GetQueue(context.Background()); err == nil {
+		t.Fatal("GetQueue() succeeded, want a network error")
+	}
+
+	spans := recorder.Ended()
+	request := spans[len(spans)-1]
+	if request.Status().Code != codes.Error || spanAttributes(request)[tracing.ErrorCodeKey].AsString() != string(ErrorCodeNetworkError) {
+		t.Errorf("request span status = %v, attributes = %v, want a NETWORK_ERROR", request.Status(), spanAttributes(request))
+	}
+}
+
+func TestJobFromPath(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantJob    string
+		wantNumber int
+	}{
+		{path: "/job/team/job/app/12/api/json", wantJob: "team/app", wantNumber: 12},
+		{path: "/job/my%20app/api/json?tree=name", wantJob: "my app"},
+		{path: "/job/app/buildWithParameters?n=1", wantJob: "app"},
+		{path: "/queue/api/json"},
+	}
+
+	for _, tt := range tests {
+		job, number := jobFromPath(tt.path)
+		if job != tt.wantJob || number != tt.wantNumber {
+			t.Errorf("jobFromPath(%q) = %q, %d, want %q, %d", tt.path, job, number, tt.wantJob, tt.wantNumber)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default polling settings for WaitForBuild
+const (
+	defaultWaitPollInterval = 2 * time.Second
+	defaultWaitTimeout      = 10 * time.Minute
+)
+
+// WaitForBuild follows a queue item until it becomes a build and then polls the
+// build until it completes or the timeout expires.
+// On timeout the most recently observed build (if any) is returned alongside the error.
+func (c *Client) WaitForBuild(ctx context.Context, jobName string, queueID int, opts WaitOptions) (*Build, error) {
+	if jobName == "" {
+		return nil, NewInvalidInputError("job name cannot be empty")
+	}
+	if queueID <= 0 {
+		return nil, NewInvalidInputError("queue ID must be positive")
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWaitPollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWaitTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	report := func(progress WaitProgress) {
+		if opts.OnProgress != nil {
+			progress.QueueID = queueID
+			progress.Elapsed = time.Since(started)
+			opts.OnProgress(progress)
+		}
+	}
+
+	// Phase 1: wait for the queue item to leave the queue
+	buildNumber := 0
+	for buildNumber == 0 {
+		item, err := c.GetQueueItem(ctx, queueID)
+		if err != nil {
+			return nil, waitError(ctx, fmt.Sprintf("queue item %d", queueID), err)
+		}
+		if item.Cancelled {
+			return nil, NewJenkinsError(fmt.Sprintf("queue item %d was cancelled before it started", queueID))
+		}
+		if item.Executable != nil && item.Executable.Number > 0 {
+			buildNumber = item.Executable.Number
+			break
+		}
+
+		report(WaitProgress{Phase: WaitPhaseQueued, Why: item.Why})
+
+		if err := sleepContext(ctx, opts.PollInterval); err != nil {
+			return nil, waitError(ctx, fmt.Sprintf("queue item %d", queueID), err)
+		}
+	}
+
+	// Phase 2: wait for the build to finish
+	var lastBuild *Build
+	for {
+		build, err := c.GetBuild(ctx, jobName, buildNumber)
+		if err != nil {
+			return lastBuild, waitError(ctx, fmt.Sprintf("build %s #%d", jobName, buildNumber), err)
+		}
+		lastBuild = build
+
+		if !build.Building {
+			report(WaitProgress{Phase: WaitPhaseCompleted, BuildNumber: buildNumber})
+			return build, nil
+		}
+
+		report(WaitProgress{
+			Phase:             WaitPhaseBuilding,
+			BuildNumber:       buildNumber,
+			EstimatedDuration: time.Duration(build.EstimatedDuration) * time.Millisecond,
+		})
+
+		if err := sleepContext(ctx, opts.PollInterval); err != nil {
+			return lastBuild, waitError(ctx, fmt.Sprintf("build %s #%d", jobName, buildNumber), err)
+		}
+	}
+}
+
+// waitError reports a deadline expiry as a timeout and passes other errors through
+func waitError(ctx context.Context, what string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return WrapError(ErrorCodeTimeout, fmt.Sprintf("timed out waiting for %s", what), ctx.Err())
+	}
+	if _, ok := GetErrorCode(err); ok {
+		return err
+	}
+	return WrapError(ErrorCodeInternalError, fmt.Sprintf("failed waiting for %s", what), err)
+}
+
+// sleepContext sleeps for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
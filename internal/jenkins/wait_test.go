@@ -0,0 +1,78 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForBuild(t *testing.T) {
+	var queuePolls, buildPolls atomic.Int32
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/queue/item/42/api/json":
+			if queuePolls.Add(1) < 2 {
+				w.Write([]byte(`{"id":42,"why":"Waiting for next available executor"}`))
+				return
+			}
+			w.Write([]byte(`{"id":42,"executable":{"number":9,"url":"http://jenkins/job/app/9/"}}`))
+		case "/job/app/9/api/json":
+			if buildPolls.Add(1) < 2 {
+				w.Write([]byte(`{"number":9,"building":true,"estimatedDuration":60000,"executor":{"_class":"hudson.model.Executor","number":0}}`))
+				return
+			}
+			w.Write([]byte(`{"number":9,"building":false,"result":"SUCCESS"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	var phases []string
+	build, err := client.WaitForBuild(context.Background(), "app", 42, WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      5 * time.Second,
+		OnProgress: func(p WaitProgress) {
+			phases = append(phases, p.Phase)
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitForBuild() error = %v", err)
+	}
+	if build.Number != 9 || build.Result != "SUCCESS" {
+		t.Errorf("WaitForBuild() = #%d %s, want #9 SUCCESS", build.Number, build.Result)
+	}
+
+	want := []string{WaitPhaseQueued, WaitPhaseBuilding, WaitPhaseCompleted}
+	if strings.Join(phases, ",") != strings.Join(want, ",") {
+		t.Errorf("progress phases = %v, want %v", phases, want)
+	}
+}
+
+func TestWaitForBuildCancelled(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":7,"cancelled":true}`))
+	}))
+
+	_, err := client.WaitForBuild(context.Background(), "app", 7, WaitOptions{PollInterval: time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("WaitForBuild() error = %v, want cancelled error", err)
+	}
+}
+
+func TestWaitForBuildTimeout(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":7,"why":"Waiting"}`))
+	}))
+
+	_, err := client.WaitForBuild(context.Background(), "app", 7, WaitOptions{
+		PollInterval: 5 * time.Millisecond,
+		Timeout:      30 * time.Millisecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("WaitForBuild() error = %v, want timeout error", err)
+	}
+}
@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/audit"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordAudit writes the audit event of a mutating tool call. A failing
+// audit sink is logged but does not fail the call, which has already run.
+func (s *Server) recordAudit(ctx context.Context, tool *mcp.Tool, request *mcp.CallToolRequest, result *mcp.CallToolResult, out any, outcome, errorCode string, duration time.Duration) {
+	if s.audit == nil {
+		return
+	}
+
+	event := audit.Event{
+		Time:       time.Now().UTC(),
+		Tool:       tool.Name,
+		Session:    auditSession(request),
+		Outcome:    outcome,
+		ErrorCode:  errorCode,
+		QueueID:    queueIDFromOutput(out),
+		DurationMs: duration.Milliseconds(),
+	}
+	if instance, err := s.resolveInstance(request); err == nil {
+		event.Instance = instance.name
+	}
+	if request != nil && request.Params != nil {
+		// The password parameters of a denied call are never looked up, so none of its
+		// parameter values are trusted to be safe to keep
+		event.Arguments = auditArguments(request.Params.Arguments, outcome == audit.OutcomeDenied)
+	}
+	if errorCode != "" {
+		event.Error = resultText(result)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		event.TraceID = spanContext.TraceID().String()
+	}
+
+	// The event is written even when the client has gone away
+	if err := s.audit.Record(context.WithoutCancel(ctx), event); err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tool":  tool.Name,
+			"error": err.Error(),
+		}).Error("Failed to write audit event")
+	}
+}
+
+// registerSecretArguments registers the values a call gives for the password
+// parameters of its job as secrets, so that they are masked in the audit log
+// and the confirmation summary even when the call is stopped before it runs.
+// It looks the job up in Jenkins and so must only run for calls the policy allows.
+func (s *Server) registerSecretArguments(ctx context.Context, request *mcp.CallToolRequest) {
+	var args struct {
+		JobName    string         `json:"jobName"`
+		Parameters map[string]any `json:"parameters"`
+	}
+	if request == nil || request.Params == nil || json.Unmarshal(request.Params.Arguments, &args) != nil ||
+		args.JobName == "" || len(args.Parameters) == 0 {
+		return
+	}
+
+	params, err := parameterValues(args.Parameters)
+	if err != nil {
+		return
+	}
+
+	jobDetails, err := s.client(ctx).GetJob(ctx, args.JobName)
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"job":   args.JobName,
+			"error": err.Error(),
+		}).Debug("Failed to look up password parameters")
+		return
+	}
+	jenkins.RegisterSecretParameters(jobDetails, params)
+}
+
+// auditSession describes the MCP session and client a call came from
+func auditSession(request *mcp.CallToolRequest) audit.Session {
+	var session audit.Session
+	if request == nil {
+		return session
+	}
+
+	if request.Session != nil {
+		session.ID = request.Session.ID()
+		if params := request.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+			session.ClientName = params.ClientInfo.Name
+			session.ClientVersion = params.ClientInfo.Version
+		}
+	}
+	if request.Extra != nil && request.Extra.Header != nil {
+		session.UserAgent = request.Extra.Header.Get("User-Agent")
+	}
+
+	return session
+}
+
+// auditArguments returns the call arguments without the confirm token, with
+// the content of uploaded files replaced by its size and with secrets, such
+// as password parameters, masked. maskParameters masks every build parameter value.
+func auditArguments(raw json.RawMessage, maskParameters bool) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var args map[string]json.RawMessage
+	if json.Unmarshal(raw, &args) == nil {
+		_, hasToken := args[confirmTokenArgument]
+		delete(args, confirmTokenArgument)
+
+		var files map[string]TriggerBuildFile
+		hasFiles := json.Unmarshal(args["files"], &files) == nil && len(files) > 0
+		if hasFiles {
+			for name, file := range files {
+				file.Content = fmt.Sprintf("<%d bytes base64>", len(file.Content))
+				files[name] = file
+			}
+			args["files"], _ = json.Marshal(files)
+		}
+
+		var params map[string]json.RawMessage
+		hasParams := maskParameters && json.Unmarshal(args["parameters"], &params) == nil && len(params) > 0
+		if hasParams {
+			for name := range params {
+				params[name], _ = json.Marshal(redact.Mask)
+			}
+			args["parameters"], _ = json.Marshal(params)
+		}
+
+		if hasToken || hasFiles || hasParams {
+			if data, err := json.Marshal(args); err == nil {
+				raw = data
+			}
+		}
+	}
+
+	masked := redact.String(string(raw))
+	if !json.Valid([]byte(masked)) {
+		// A pattern spanning JSON syntax broke the document; keep it as a string
+		data, _ := json.Marshal(masked)
+		return data
+	}
+	return json.RawMessage(masked)
+}
+
+// queueIDFromOutput returns the queue item ID a call produced, or 0 when it produced none
+func queueIDFromOutput(out any) int {
+	switch out := out.(type) {
+	case *jenkins.QueueItem:
+		if out != nil {
+			return out.ID
+		}
+	case *jenkins.Rebuild:
+		if out != nil && out.QueueItem != nil {
+			return out.QueueItem.ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/audit"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+func TestPasswordParametersMaskedBeforeTheCallRuns(t *testing.T) {
+	auditFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	var paths []string
+	session := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		// SIGNING_PASS is a password parameter whose name does not look sensitive
+		w.Write([]byte(`{"name":"deploy","buildable":true,"property":[{"parameterDefinitions":[
+			{"name":"SIGNING_PASS","type":"PasswordParameterDefinition","defaultParameterValue":{"value":""}},
+			{"name":"ENV","type":"StringParameterDefinition","defaultParameterValue":{"value":"staging"}}]}]}`))
+	}), func(cfg *config.Config) {
+		cfg.Audit.File = auditFile
+		cfg.Confirmation = config.ConfirmationConfig{Mode: config.ConfirmModeToken, Tools: []string{"jenkins_trigger_build"}}
+		cfg.Policy.JobRestrictions = map[string][]string{"jenkins_trigger_build": {"deploy"}}
+	})
+
+	confirmation := callTool(t, session, "jenkins_trigger_build", map[string]any{
+		"jobName": "deploy", "parameters": map[string]any{"SIGNING_PASS": "first-signing-secret", "ENV": "prod"},
+	})
+	if text := resultText(confirmation); strings.Contains(text, "first-signing-secret") || !strings.Contains(text, "ENV: prod") {
+		t.Errorf("confirmation summary = %q, want the password masked and other parameters shown", text)
+	}
+
+	denied := callTool(t, session, "jenkins_trigger_build", map[string]any{
+		"jobName": "other", "parameters": map[string]any{"SIGNING_PASS": "second-signing-secret"},
+	})
+	if resultErrorCode(denied) == "" {
+		t.Fatalf("call of a restricted job succeeded: %s", resultText(denied))
+	}
+	for _, path := range paths {
+		if strings.HasPrefix(path, "/job/other/") {
+			t.Errorf("denied call reached Jenkins: %s", path)
+		}
+	}
+
+	data, err := os.ReadFile(auditFile)
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	log := string(data)
+	for _, outcome := range []string{audit.OutcomeConfirmationRequired, audit.OutcomeDenied} {
+		if !strings.Contains(log, `"outcome":"`+outcome+`"`) {
+			t.Errorf("audit log has no %s event:\n%s", outcome, log)
+		}
+	}
+	if strings.Contains(log, "first-signing-secret") || strings.Contains(log, "second-signing-secret") {
+		t.Errorf("audit log contains a password parameter:\n%s", log)
+	}
+}
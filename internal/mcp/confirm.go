@@ -0,0 +1,277 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// confirmTokenArgument is the extra tool argument used for two-phase confirmation
+	confirmTokenArgument = "confirmToken"
+
+	// maxSummaryValueLength caps how much of a single argument is shown when asking for confirmation
+	maxSummaryValueLength = 200
+)
+
+// confirmSchema is the form presented to the user through elicitation
+var confirmSchema = &jsonschema.Schema{
+	Type: "object",
+	Properties: map[string]*jsonschema.Schema{
+		"confirm": {Type: "boolean", Description: "Run this action against Jenkins"},
+	},
+	Required: []string{"confirm"},
+}
+
+// confirmTokenSchema describes the confirmToken argument added to confirmable tools
+var confirmTokenSchema = &jsonschema.Schema{
+	Type:        "string",
+	Description: "Confirm token returned by a previous call to this tool with the same arguments",
+}
+
+// Confirmer makes the client explicitly confirm a tool call before it runs,
+// either through MCP elicitation or with a single-use confirm token
+type Confirmer struct {
+	cfg    config.ConfirmationConfig
+	now    func() time.Time
+	mu     sync.Mutex
+	tokens map[string]pendingConfirmation
+}
+
+// pendingConfirmation is a confirm token waiting to be redeemed
+type pendingConfirmation struct {
+	tool      string
+	sessionID string
+	digest    string
+	expires   time.Time
+}
+
+// NewConfirmer creates a confirmer from configuration
+func NewConfirmer(cfg config.ConfirmationConfig) *Confirmer {
+	if cfg.Mode == "" {
+		cfg.Mode = config.ConfirmModeAuto
+	}
+	if cfg.TokenTTL == 0 {
+		cfg.TokenTTL = 5 * time.Minute
+	}
+
+	return &Confirmer{
+		cfg:    cfg,
+		now:    time.Now,
+		tokens: make(map[string]pendingConfirmation),
+	}
+}
+
+// Requires reports whether calls to the tool must be confirmed
+func (c *Confirmer) Requires(toolName string) bool {
+	if c.cfg.Mode == config.ConfirmModeOff {
+		return false
+	}
+	_, ok := matchAny(c.cfg.Tools, toolName)
+	return ok
+}
+
+// AcceptsToken reports whether the tool takes a confirmToken argument
+func (c *Confirmer) AcceptsToken(toolName string) bool {
+	return c.Requires(toolName) && c.cfg.Mode != config.ConfirmModeElicit
+}
+
+// Confirm obtains confirmation for a tool call. A nil result and nil error
+// mean the call may proceed. A non-nil result means a confirm token was issued
+// and must be returned to the client instead of running the tool.
+func (c *Confirmer) Confirm(ctx context.Context, tool *mcp.Tool, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := map[string]any{}
+	if request.Params != nil && len(request.Params.Arguments) > 0 {
+		if err := json.Unmarshal(request.Params.Arguments, &args); err != nil {
+			return nil, jenkins.NewInvalidInputError("arguments must be a JSON object")
+		}
+	}
+
+	token, _ := args[confirmTokenArgument].(string)
+	delete(args, confirmTokenArgument)
+
+	var sessionID string
+	if request.Session != nil {
+		sessionID = request.Session.ID()
+	}
+
+	if token != "" && c.AcceptsToken(tool.Name) {
+		return nil, c.redeem(tool.Name, sessionID, args, token)
+	}
+
+	switch c.cfg.Mode {
+	case config.ConfirmModeElicit:
+		if !supportsElicitation(request.Session) {
+			return nil, jenkins.NewPermissionDeniedError(fmt.Sprintf("%s requires confirmation but the client does not support elicitation", tool.Name))
+		}
+		return nil, c.elicit(ctx, tool, request.Session, args)
+	case config.ConfirmModeAuto:
+		if supportsElicitation(request.Session) {
+			return nil, c.elicit(ctx, tool, request.Session, args)
+		}
+	}
+
+	return c.issue(tool, sessionID, args)
+}
+
+// elicit asks the user to approve the call through the client
+func (c *Confirmer) elicit(ctx context.Context, tool *mcp.Tool, session *mcp.ServerSession, args map[string]any) error {
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         fmt.Sprintf("Confirm %s?\n\n%s", tool.Name, summarizeArguments(args)),
+		RequestedSchema: confirmSchema,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request confirmation: %w", err)
+	}
+
+	if result.Action != "accept" || result.Content["confirm"] != true {
+		return jenkins.NewPermissionDeniedError(fmt.Sprintf("%s was not confirmed by the user", tool.Name))
+	}
+
+	return nil
+}
+
+// issue creates a confirm token bound to the tool, session and exact arguments
+func (c *Confirmer) issue(tool *mcp.Tool, sessionID string, args map[string]any) (*mcp.CallToolResult, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate confirm token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	c.mu.Lock()
+	c.purgeExpired()
+	c.tokens[token] = pendingConfirmation{
+		tool:      tool.Name,
+		sessionID: sessionID,
+		digest:    digestArguments(args),
+		expires:   c.now().Add(c.cfg.TokenTTL),
+	}
+	c.mu.Unlock()
+
+	msg := fmt.Sprintf(`⚠️ CONFIRMATION REQUIRED
+
+%s will be run with:
+%s
+
+This action has NOT been performed yet.
+
+🤖 AI Action Required:
+Show these details to the user. Only after they explicitly approve, call %s again with exactly the same arguments plus "%s": "%s".
+The token can be used once and expires in %s.`,
+		tool.Name,
+		summarizeArguments(args),
+		tool.Name,
+		confirmTokenArgument,
+		token,
+		c.cfg.TokenTTL,
+	)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: msg},
+		},
+	}, nil
+}
+
+// redeem consumes a confirm token, checking that it was issued for this exact call
+func (c *Confirmer) redeem(toolName, sessionID string, args map[string]any, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending, ok := c.tokens[token]
+	if !ok || c.now().After(pending.expires) {
+		delete(c.tokens, token)
+		return jenkins.NewInvalidInputError("confirm token is unknown or has expired; call the tool again without it to get a new one")
+	}
+
+	if pending.tool != toolName || pending.sessionID != sessionID || pending.digest != digestArguments(args) {
+		return jenkins.NewInvalidInputError("confirm token was issued for a different call; the arguments must match the confirmed ones exactly")
+	}
+
+	delete(c.tokens, token)
+	return nil
+}
+
+// purgeExpired drops tokens past their expiry. The caller must hold c.mu.
+func (c *Confirmer) purgeExpired() {
+	now := c.now()
+	for token, pending := range c.tokens {
+		if now.After(pending.expires) {
+			delete(c.tokens, token)
+		}
+	}
+}
+
+// supportsElicitation reports whether the connected client can answer elicitation requests
+func supportsElicitation(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// digestArguments hashes the arguments in canonical (sorted key) JSON form
+func digestArguments(args map[string]any) string {
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// summarizeArguments renders tool arguments as an indented list for the user
+// to review, with secrets masked
+func summarizeArguments(args map[string]any) string {
+	if len(args) == 0 {
+		return "  (no arguments)"
+	}
+
+	var lines []string
+	for _, key := range sortedKeys(args) {
+		if nested, ok := args[key].(map[string]any); ok && len(nested) > 0 {
+			lines = append(lines, fmt.Sprintf("  %s:", key))
+			for _, nestedKey := range sortedKeys(nested) {
+				lines = append(lines, fmt.Sprintf("    %s: %s", nestedKey, redact.Value(nestedKey, summarizeValue(nested[nestedKey]))))
+			}
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", key, redact.Value(key, summarizeValue(args[key]))))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// summarizeValue formats a single argument value, eliding long text
+func summarizeValue(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		data, _ := json.Marshal(v)
+		s = string(data)
+	}
+	if len(s) > maxSummaryValueLength {
+		return fmt.Sprintf("<%d characters>", len(s))
+	}
+	return s
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
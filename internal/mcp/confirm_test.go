@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// confirmTokenPattern extracts the confirm token from a confirmation request
+var confirmTokenPattern = regexp.MustCompile(`"confirmToken": "([0-9a-f]+)"`)
+
+// cancelJenkins accepts queue item cancellations, counting them
+func cancelJenkins(cancels *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/queue/cancelItem" {
+			cancels.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// confirmConfig requires confirmation of jenkins_cancel_queue_item in the given mode
+func confirmConfig(mode string) func(*config.Config) {
+	return func(cfg *config.Config) {
+		cfg.Confirmation = config.ConfirmationConfig{Mode: mode, Tools: []string{"jenkins_cancel_queue_item"}}
+	}
+}
+
+func TestConfirmToken(t *testing.T) {
+	var cancels atomic.Int32
+	session := newTestSession(t, cancelJenkins(&cancels), confirmConfig(config.ConfirmModeToken))
+	args := map[string]any{"queueId": 5}
+
+	result := callTool(t, session, "jenkins_cancel_queue_item", args)
+	match := confirmTokenPattern.FindStringSubmatch(resultText(result))
+	if match == nil || result.IsError {
+		t.Fatalf("first call = %q, want a confirm token", resultText(result))
+	}
+	if cancels.Load() != 0 {
+		t.Fatal("queue item cancelled before the call was confirmed")
+	}
+	token := match[1]
+
+	// The token is bound to the exact arguments
+	other := map[string]any{"queueId": 6, "confirmToken": token}
+	if code := resultErrorCode(callTool(t, session, "jenkins_cancel_queue_item", other)); code != string(jenkins.ErrorCodeInvalidInput) {
+		t.Errorf("call with other arguments error code = %q, want %s", code, jenkins.ErrorCodeInvalidInput)
+	}
+
+	confirmed := map[string]any{"queueId": 5, "confirmToken": token}
+	if result := callTool(t, session, "jenkins_cancel_queue_item", confirmed); result.IsError {
+		t.Fatalf("confirmed call failed: %s", resultText(result))
+	}
+	if cancels.Load() != 1 {
+		t.Errorf("Jenkins received %d cancellations, want 1", cancels.Load())
+	}
+
+	// Tokens are single-use
+	if code := resultErrorCode(callTool(t, session, "jenkins_cancel_queue_item", confirmed)); code != string(jenkins.ErrorCodeInvalidInput) {
+		t.Errorf("reused token error code = %q, want %s", code, jenkins.ErrorCodeInvalidInput)
+	}
+	if cancels.Load() != 1 {
+		t.Errorf("Jenkins received %d cancellations after the token was reused, want 1", cancels.Load())
+	}
+}
+
+func TestConfirmTokenDigestAndExpiry(t *testing.T) {
+	confirmer := NewConfirmer(config.ConfirmationConfig{Mode: config.ConfirmModeToken, TokenTTL: time.Minute})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	confirmer.now = func() time.Time { return now }
+	tool := &mcp.Tool{Name: "jenkins_cancel_queue_item"}
+
+	issue := func(args map[string]any) string {
+		t.Helper()
+		result, err := confirmer.issue(tool, "session-1", args)
+		if err != nil {
+			t.Fatalf("issue() error = %v", err)
+		}
+		return confirmTokenPattern.FindStringSubmatch(resultText(result))[1]
+	}
+
+	// The digest does not depend on key order or on how numbers were decoded
+	token := issue(map[string]any{"jobName": "app", "buildNumber": 5.0, "parameters": map[string]any{"A": "1", "B": "2"}})
+	if err := confirmer.redeem(tool.Name, "session-1", map[string]any{"parameters": map[string]any{"B": "2", "A": "1"}, "buildNumber": 5, "jobName": "app"}, token); err != nil {
+		t.Errorf("redeem() with the same arguments error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		toolName  string
+		sessionID string
+		args      map[string]any
+		elapsed   time.Duration
+	}{
+		{name: "other tool", toolName: "jenkins_delete_job", sessionID: "session-1", args: map[string]any{"jobName": "app"}},
+		{name: "other session", toolName: tool.Name, sessionID: "session-2", args: map[string]any{"jobName": "app"}},
+		{name: "other arguments", toolName: tool.Name, sessionID: "session-1", args: map[string]any{"jobName": "app", "buildNumber": 1}},
+		{name: "expired", toolName: tool.Name, sessionID: "session-1", args: map[string]any{"jobName": "app"}, elapsed: time.Minute + time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := issue(map[string]any{"jobName": "app"})
+			now = now.Add(tt.elapsed)
+
+			err := confirmer.redeem(tt.toolName, tt.sessionID, tt.args, token)
+			if !jenkins.IsErrorCode(err, jenkins.ErrorCodeInvalidInput) {
+				t.Errorf("redeem() error = %v, want %s", err, jenkins.ErrorCodeInvalidInput)
+			}
+		})
+	}
+}
+
+func TestConfirmElicitation(t *testing.T) {
+	// answer returns an elicitation handler that answers with the given action
+	answer := func(action string, confirm bool) *mcp.ClientOptions {
+		return &mcp.ClientOptions{
+			ElicitationHandler: func(ctx context.Context, request *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: action, Content: map[string]any{"confirm": confirm}}, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		clientOptions *mcp.ClientOptions
+		wantCode      string
+		wantToken     bool
+		wantCancelled bool
+	}{
+		{name: "accepted", mode: config.ConfirmModeAuto, clientOptions: answer("accept", true), wantCancelled: true},
+		{name: "accepted without confirming", mode: config.ConfirmModeAuto, clientOptions: answer("accept", false),
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+		{name: "declined", mode: config.ConfirmModeElicit, clientOptions: answer("decline", false),
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+		{name: "auto falls back to a token", mode: config.ConfirmModeAuto, wantToken: true},
+		{name: "elicit without client support", mode: config.ConfirmModeElicit,
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cancels atomic.Int32
+			session := newTestSessionWithClient(t, cancelJenkins(&cancels), confirmConfig(tt.mode), tt.clientOptions)
+
+			result := callTool(t, session, "jenkins_cancel_queue_item", map[string]any{"queueId": 5})
+
+			if code := resultErrorCode(result); code != tt.wantCode {
+				t.Errorf("error code = %q, want %q (%s)", code, tt.wantCode, resultText(result))
+			}
+			if hasToken := confirmTokenPattern.MatchString(resultText(result)); hasToken != tt.wantToken {
+				t.Errorf("confirm token issued = %v, want %v", hasToken, tt.wantToken)
+			}
+			if cancelled := cancels.Load() > 0; cancelled != tt.wantCancelled {
+				t.Errorf("queue item cancelled = %v, want %v", cancelled, tt.wantCancelled)
+			}
+		})
+	}
+}
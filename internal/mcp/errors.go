@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolErrorResult renders a failed tool call as an IsError tool result.
+// The text starts with the error code so agents can tell NOT_FOUND from
+// AUTH_FAILED, and the code and details are repeated in the result's _meta
+// for clients that consume them programmatically. Errors that do not carry
+// an ErrorResponse are reported as INTERNAL_ERROR.
+func toolErrorResult(err error) *mcp.CallToolResult {
+	code := jenkins.ErrorCodeInternalError
+	message := err.Error()
+	var details map[string]interface{}
+
+	var errResp *jenkins.ErrorResponse
+	if errors.As(err, &errResp) {
+		code = errResp.Code
+		details = errResp.Details
+		// Keep the handler's context but drop the code and details already
+		// rendered by ErrorResponse.Error
+		message = strings.Replace(message, errResp.Error(), errResp.Message, 1)
+	}
+
+	lines := []string{fmt.Sprintf("Error [%s]: %s", code, message)}
+	for _, key := range sortedDetailKeys(details) {
+		// The raw response body is usually an HTML page; it is only kept in _meta
+		if key == "body" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %v", key, details[key]))
+	}
+
+	meta := mcp.Meta{"errorCode": string(code)}
+	if len(details) > 0 {
+		meta["errorDetails"] = details
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: redact.String(strings.Join(lines, "\n"))},
+		},
+		IsError: true,
+		Meta:    meta,
+	}
+}
+
+// resultErrorCode returns the error code of an IsError result rendered by
+// toolErrorResult, or "" when the call succeeded
+func resultErrorCode(result *mcp.CallToolResult) string {
+	if result == nil || !result.IsError {
+		return ""
+	}
+	if code, ok := result.Meta["errorCode"].(string); ok {
+		return code
+	}
+	return string(jenkins.ErrorCodeInternalError)
+}
+
+// resultText returns the text of the first content item of a result
+func resultText(result *mcp.CallToolResult) string {
+	if result == nil || len(result.Content) == 0 {
+		return ""
+	}
+	if text, ok := result.Content[0].(*mcp.TextContent); ok {
+		return text.Text
+	}
+	return ""
+}
+
+func sortedDetailKeys(details map[string]interface{}) []string {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
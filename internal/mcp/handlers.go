@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 )
@@ -21,18 +24,26 @@ type ServerHealthArgs struct {
 
 // ListJobsArgs defines the input parameters for jenkins_list_jobs
 type ListJobsArgs struct {
-	Folder string `json:"folder,omitempty" jsonschema_description:"Optional folder path to list jobs from a specific folder"`
+	Folder    string `json:"folder,omitempty" jsonschema_description:"Optional folder path to list jobs from a specific folder (e.g. team/service)"`
+	Recursive bool   `json:"recursive,omitempty" jsonschema_description:"Also list jobs inside nested folders and multibranch projects"`
 }
 
 // handleListJobs handles the jenkins_list_jobs tool call
-func (s *Server) handleListJobs(ctx context.Context, request *mcp.CallToolRequest, args ListJobsArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleListJobs(ctx context.Context, request *mcp.CallToolRequest, args ListJobsArgs) (*mcp.CallToolResult, *JobListOutput, error) {
 	s.log.WithFields(logrus.Fields{
-		"tool":   "jenkins_list_jobs",
-		"folder": args.Folder,
+		"tool":      "jenkins_list_jobs",
+		"folder":    args.Folder,
+		"recursive": args.Recursive,
 	}).Debug("Handling list jobs request")
 
 	// Call Jenkins client
-	jobs, err := s.jenkinsClient.ListJobs(ctx, args.Folder)
+	var jobs []jenkins.Job
+	var err error
+	if args.Recursive {
+		jobs, err = s.client(ctx).ListJobsRecursive(ctx, args.Folder)
+	} else {
+		jobs, err = s.client(ctx).ListJobs(ctx, args.Folder)
+	}
 	if err != nil {
 		s.log.WithFields(logrus.Fields{
 			"tool":   "jenkins_list_jobs",
@@ -48,55 +59,51 @@ func (s *Server) handleListJobs(ctx context.Context, request *mcp.CallToolReques
 		"job_count": len(jobs),
 	}).Info("Successfully listed jobs")
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(jobs, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &JobListOutput{Jobs: jobs}, nil
 }
 
 // GetJobArgs defines the input parameters for jenkins_get_job
 type GetJobArgs struct {
-	JobName string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
+	JobName string `json:"jobName" jsonschema_description:"Full name of the Jenkins job, using '/' to separate folders (e.g. team/service/main)"`
 }
 
 // handleGetJob handles the jenkins_get_job tool call
-func (s *Server) handleGetJob(ctx context.Context, request *mcp.CallToolRequest, args GetJobArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetJob(ctx context.Context, request *mcp.CallToolRequest, args GetJobArgs) (*mcp.CallToolResult, *jenkins.JobDetails, error) {
 	// Call Jenkins client
-	jobDetails, err := s.jenkinsClient.GetJob(ctx, args.JobName)
+	jobDetails, err := s.client(ctx).GetJob(ctx, args.JobName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get job details: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(jobDetails, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, jobDetails, nil
 }
 
 // TriggerBuildArgs defines the input parameters for jenkins_trigger_build
 type TriggerBuildArgs struct {
-	JobName    string            `json:"jobName" jsonschema_description:"Name of the Jenkins job to trigger"`
-	Parameters map[string]string `json:"parameters,omitempty" jsonschema_description:"Optional build parameters as key-value pairs"`
+	JobName    string                      `json:"jobName" jsonschema_description:"Name of the Jenkins job to trigger"`
+	Parameters map[string]any              `json:"parameters,omitempty" jsonschema_description:"Optional build parameters as key-value pairs. Boolean parameters take true or false; choice parameters one of the choices listed by jenkins_get_job"`
+	Files      map[string]TriggerBuildFile `json:"files,omitempty" jsonschema_description:"Optional files for file parameters, keyed by parameter name"`
+}
+
+// TriggerBuildFile is a file uploaded for a file parameter
+type TriggerBuildFile struct {
+	FileName string `json:"fileName,omitempty" jsonschema_description:"File name (defaults to the parameter name)"`
+	Content  string `json:"content" jsonschema_description:"Base64-encoded file content"`
 }
 
 // handleTriggerBuild handles the jenkins_trigger_build tool call
-func (s *Server) handleTriggerBuild(ctx context.Context, request *mcp.CallToolRequest, args TriggerBuildArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleTriggerBuild(ctx context.Context, request *mcp.CallToolRequest, args TriggerBuildArgs) (*mcp.CallToolResult, *jenkins.QueueItem, error) {
+	params, err := parameterValues(args.Parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := buildFiles(args.Files)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// First, get job details to check if it has parameters
-	jobDetails, err := s.jenkinsClient.GetJob(ctx, args.JobName)
+	jobDetails, err := s.client(ctx).GetJob(ctx, args.JobName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get job details: %w", err)
 	}
@@ -104,7 +111,7 @@ func (s *Server) handleTriggerBuild(ctx context.Context, request *mcp.CallToolRe
 	// Check if the job has parameters defined
 	if len(jobDetails.Parameters) > 0 {
 		// Job has parameters - check if user provided them
-		if len(args.Parameters) == 0 {
+		if len(params) == 0 && len(files) == 0 {
 			// Parameters are required but not provided
 			// Build a helpful message for the AI to ask the user
 			var paramList []string
@@ -113,11 +120,14 @@ func (s *Server) handleTriggerBuild(ctx context.Context, request *mcp.CallToolRe
 				if param.DefaultValue != nil {
 					defaultVal = fmt.Sprintf(" (default: %v)", param.DefaultValue)
 				}
+				if len(param.Choices) > 0 {
+					defaultVal += fmt.Sprintf(" [choices: %s]", strings.Join(param.Choices, ", "))
+				}
 				paramDesc := param.Description
 				if paramDesc != "" {
 					paramDesc = " - " + paramDesc
 				}
-				paramList = append(paramList, fmt.Sprintf("  • %s (%s)%s%s", param.Name, param.Type, defaultVal, paramDesc))
+				paramList = append(paramList, fmt.Sprintf("  • %s (%s)%s%s", param.Name, param.Kind, defaultVal, paramDesc))
 			}
 
 			warningMsg := fmt.Sprintf(
@@ -148,13 +158,17 @@ Once the user provides the parameters, call this tool again with the parameters
 
 		// Parameters provided - validate that all required parameters are present
 		providedParams := make(map[string]bool)
-		for key := range args.Parameters {
+		for key := range params {
+			providedParams[key] = true
+		}
+		for key := range files {
 			providedParams[key] = true
 		}
 
 		var missingParams []string
 		for _, param := range jobDetails.Parameters {
-			if !providedParams[param.Name] {
+			// File parameters are optional; Jenkins runs the build without the file
+			if !providedParams[param.Name] && param.Kind != jenkins.ParameterKindFile {
 				// Check if parameter has a default value
 				if param.DefaultValue == nil || param.DefaultValue == "" {
 					missingParams = append(missingParams, param.Name)
@@ -179,14 +193,17 @@ Please ask the user to provide values for these missing parameters.`,
 		}
 	}
 
-	// All validations passed - trigger the build
+	// All validations passed - trigger the build. Password parameters are
+	// registered as secrets first so they are masked from here on.
+	jenkins.RegisterSecretParameters(jobDetails, params)
 	s.log.WithFields(logrus.Fields{
 		"tool":       "jenkins_trigger_build",
 		"job":        args.JobName,
-		"parameters": args.Parameters,
+		"parameters": params,
+		"files":      len(files),
 	}).Info("Triggering Jenkins build")
 
-	queueItem, err := s.jenkinsClient.TriggerBuild(ctx, args.JobName, args.Parameters)
+	queueItem, err := s.client(ctx).TriggerBuildWithFiles(ctx, args.JobName, params, files)
 	if err != nil {
 		s.log.WithFields(logrus.Fields{
 			"tool":  "jenkins_trigger_build",
@@ -208,13 +225,75 @@ Please ask the user to provide values for these missing parameters.`,
 		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	successMsg := fmt.Sprintf("✅ Build triggered successfully!\n\n%s", string(result))
+	successMsg := fmt.Sprintf("✅ Build triggered successfully!\n\n%s\n\nUse jenkins_wait_for_build with this queueId to follow the build to completion.", string(result))
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: successMsg},
 		},
-	}, nil, nil
+	}, queueItem, nil
+}
+
+// parameterValues converts build parameter values given as JSON to the
+// strings Jenkins expects. Lists, e.g. for multi-select parameters, are
+// joined with commas.
+func parameterValues(args map[string]any) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	params := make(map[string]string, len(args))
+	for name, value := range args {
+		s, err := parameterValue(value)
+		if err != nil {
+			return nil, jenkins.NewInvalidInputError(fmt.Sprintf("invalid parameter: %s %v", name, err))
+		}
+		params[name] = s
+	}
+	return params, nil
+}
+
+// parameterValue converts a single JSON parameter value to a string
+func parameterValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := parameterValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("must be a string, number, boolean or list, got %T", value)
+	}
+}
+
+// buildFiles decodes the base64 content of the files given for file parameters
+func buildFiles(args map[string]TriggerBuildFile) (map[string]jenkins.BuildFile, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	files := make(map[string]jenkins.BuildFile, len(args))
+	for name, file := range args {
+		content, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return nil, jenkins.NewInvalidInputError(fmt.Sprintf("invalid file for parameter %s: content must be base64 encoded", name))
+		}
+		files[name] = jenkins.BuildFile{FileName: file.FileName, Content: content}
+	}
+	return files, nil
 }
 
 // GetBuildArgs defines the input parameters for jenkins_get_build
@@ -224,33 +303,23 @@ type GetBuildArgs struct {
 }
 
 // handleGetBuild handles the jenkins_get_build tool call
-func (s *Server) handleGetBuild(ctx context.Context, request *mcp.CallToolRequest, args GetBuildArgs) (*mcp.CallToolResult, any, error) {
-	var build interface{}
+func (s *Server) handleGetBuild(ctx context.Context, request *mcp.CallToolRequest, args GetBuildArgs) (*mcp.CallToolResult, *jenkins.Build, error) {
+	var build *jenkins.Build
 	var err error
 
 	if args.BuildNumber != nil {
 		// Get specific build
-		build, err = s.jenkinsClient.GetBuild(ctx, args.JobName, *args.BuildNumber)
+		build, err = s.client(ctx).GetBuild(ctx, args.JobName, *args.BuildNumber)
 	} else {
 		// Get latest build
-		build, err = s.jenkinsClient.GetLatestBuild(ctx, args.JobName)
+		build, err = s.client(ctx).GetLatestBuild(ctx, args.JobName)
 	}
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get build: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(build, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, build, nil
 }
 
 // GetBuildLogArgs defines the input parameters for jenkins_get_build_log
@@ -268,14 +337,14 @@ func (s *Server) handleGetBuildLog(ctx context.Context, request *mcp.CallToolReq
 
 	if args.SizeLimit != nil && *args.SizeLimit > 0 {
 		// Use the internal method with size limit
-		if client, ok := s.jenkinsClient.(*jenkins.Client); ok {
+		if client, ok := s.client(ctx).(*jenkins.Client); ok {
 			log, err = client.GetBuildLogWithLimit(ctx, args.JobName, args.BuildNumber, *args.SizeLimit)
 		} else {
 			// Fallback to regular method
-			log, err = s.jenkinsClient.GetBuildLog(ctx, args.JobName, args.BuildNumber)
+			log, err = s.client(ctx).GetBuildLog(ctx, args.JobName, args.BuildNumber)
 		}
 	} else {
-		log, err = s.jenkinsClient.GetBuildLog(ctx, args.JobName, args.BuildNumber)
+		log, err = s.client(ctx).GetBuildLog(ctx, args.JobName, args.BuildNumber)
 	}
 
 	if err != nil {
@@ -284,11 +353,31 @@ func (s *Server) handleGetBuildLog(ctx context.Context, request *mcp.CallToolReq
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: log},
+			&mcp.TextContent{Text: redact.String(log)},
 		},
 	}, nil, nil
 }
 
+// GetBuildLogProgressiveArgs defines the input parameters for jenkins_get_build_log_progressive
+type GetBuildLogProgressiveArgs struct {
+	JobName     string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
+	BuildNumber int    `json:"buildNumber" jsonschema_description:"Build number"`
+	Start       int64  `json:"start,omitempty" jsonschema_description:"Byte offset to read from; pass nextStart from the previous call to follow a running build (default 0)"`
+	TailLines   int    `json:"tailLines,omitempty" jsonschema_description:"Only return the last N lines of the log from start (0 for all)"`
+}
+
+// handleGetBuildLogProgressive handles the jenkins_get_build_log_progressive tool call
+func (s *Server) handleGetBuildLogProgressive(ctx context.Context, request *mcp.CallToolRequest, args GetBuildLogProgressiveArgs) (*mcp.CallToolResult, *jenkins.LogChunk, error) {
+	// Call Jenkins client
+	chunk, err := s.client(ctx).GetBuildLogProgressive(ctx, args.JobName, args.BuildNumber, args.Start, args.TailLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get build log: %w", err)
+	}
+	chunk.Text = redact.String(chunk.Text)
+
+	return nil, chunk, nil
+}
+
 // ListArtifactsArgs defines the input parameters for jenkins_list_artifacts
 type ListArtifactsArgs struct {
 	JobName     string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
@@ -296,24 +385,14 @@ type ListArtifactsArgs struct {
 }
 
 // handleListArtifacts handles the jenkins_list_artifacts tool call
-func (s *Server) handleListArtifacts(ctx context.Context, request *mcp.CallToolRequest, args ListArtifactsArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleListArtifacts(ctx context.Context, request *mcp.CallToolRequest, args ListArtifactsArgs) (*mcp.CallToolResult, *ArtifactListOutput, error) {
 	// Call Jenkins client
-	artifacts, err := s.jenkinsClient.ListArtifacts(ctx, args.JobName, args.BuildNumber)
+	artifacts, err := s.client(ctx).ListArtifacts(ctx, args.JobName, args.BuildNumber)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list artifacts: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(artifacts, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &ArtifactListOutput{Artifacts: artifacts}, nil
 }
 
 // GetArtifactArgs defines the input parameters for jenkins_get_artifact
@@ -324,58 +403,40 @@ type GetArtifactArgs struct {
 }
 
 // handleGetArtifact handles the jenkins_get_artifact tool call
-func (s *Server) handleGetArtifact(ctx context.Context, request *mcp.CallToolRequest, args GetArtifactArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetArtifact(ctx context.Context, request *mcp.CallToolRequest, args GetArtifactArgs) (*mcp.CallToolResult, *ArtifactContentOutput, error) {
 	// Call Jenkins client
-	artifactData, err := s.jenkinsClient.GetArtifact(ctx, args.JobName, args.BuildNumber, args.ArtifactPath)
+	artifactData, err := s.client(ctx).GetArtifact(ctx, args.JobName, args.BuildNumber, args.ArtifactPath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get artifact: %w", err)
 	}
 
+	// Mask secrets in text artifacts; binary artifacts are returned as they are
+	artifactData = redact.Bytes(artifactData)
+
 	// Encode artifact as base64 for safe transport
 	encoded := base64.StdEncoding.EncodeToString(artifactData)
 
 	// Return with metadata
-	response := map[string]interface{}{
-		"artifactPath": args.ArtifactPath,
-		"size":         len(artifactData),
-		"encoding":     "base64",
-		"content":      encoded,
-	}
-
-	result, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &ArtifactContentOutput{
+		ArtifactPath: args.ArtifactPath,
+		Size:         len(artifactData),
+		Encoding:     "base64",
+		Content:      encoded,
+	}, nil
 }
 
 // GetQueueArgs defines the input parameters for jenkins_get_queue (no parameters needed)
 type GetQueueArgs struct{}
 
 // handleGetQueue handles the jenkins_get_queue tool call
-func (s *Server) handleGetQueue(ctx context.Context, request *mcp.CallToolRequest, args GetQueueArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetQueue(ctx context.Context, request *mcp.CallToolRequest, args GetQueueArgs) (*mcp.CallToolResult, *QueueOutput, error) {
 	// Call Jenkins client
-	queueItems, err := s.jenkinsClient.GetQueue(ctx)
+	queueItems, err := s.client(ctx).GetQueue(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get queue: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(queueItems, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &QueueOutput{Items: queueItems}, nil
 }
 
 // StopBuildArgs defines the input parameters for jenkins_stop_build
@@ -385,71 +446,57 @@ type StopBuildArgs struct {
 }
 
 // handleStopBuild handles the jenkins_stop_build tool call
-func (s *Server) handleStopBuild(ctx context.Context, request *mcp.CallToolRequest, args StopBuildArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleStopBuild(ctx context.Context, request *mcp.CallToolRequest, args StopBuildArgs) (*mcp.CallToolResult, *StopBuildOutput, error) {
 	// Call Jenkins client
-	err := s.jenkinsClient.StopBuild(ctx, args.JobName, args.BuildNumber)
+	err := s.client(ctx).StopBuild(ctx, args.JobName, args.BuildNumber)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to stop build: %w", err)
 	}
 
 	// Return confirmation
-	response := map[string]interface{}{
-		"success":     true,
-		"message":     fmt.Sprintf("Build %d for job %s has been stopped", args.BuildNumber, args.JobName),
-		"jobName":     args.JobName,
-		"buildNumber": args.BuildNumber,
-	}
-
-	result, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &StopBuildOutput{
+		Success:     true,
+		Message:     fmt.Sprintf("Build %d for job %s has been stopped", args.BuildNumber, args.JobName),
+		JobName:     args.JobName,
+		BuildNumber: args.BuildNumber,
+	}, nil
 }
 
 // handleServerHealthStatus handles the jenkins_server_health tool call
-func (s *Server) handleServerHealthStatus(ctx context.Context, request *mcp.CallToolRequest, args ServerHealthArgs) (*mcp.CallToolResult, any, error) {
-	url := s.config.JenkinsURL
+func (s *Server) handleServerHealthStatus(ctx context.Context, request *mcp.CallToolRequest, args ServerHealthArgs) (*mcp.CallToolResult, *ServerHealthOutput, error) {
+	url := s.instance(ctx).url
 	healthURL := fmt.Sprintf("%s/health", url)
 
 	// Send an HTTP GET request to the /health endpoint
-	resp, err := http.Get(healthURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get server health status from %s: %w", healthURL, err)
+		return nil, nil, jenkins.WrapError(jenkins.ErrorCodeInternalError, "failed to create health request", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, jenkins.NewRequestError(req, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, jenkins.NewHTTPError(resp, "server health status")
+	}
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-	var healthResponse struct {
-		Status bool `json:"status"`
+		return nil, nil, jenkins.WrapError(jenkins.ErrorCodeJenkinsError, "failed to read response body", err)
 	}
+	var healthResponse ServerHealthOutput
 
 	if err := json.Unmarshal(body, &healthResponse); err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, nil, jenkins.WrapError(jenkins.ErrorCodeJenkinsError, "failed to unmarshal response", err)
 	}
 
 	if !healthResponse.Status {
-		return nil, nil, fmt.Errorf("server health status is false")
-	}
-
-	result, err := json.MarshalIndent(healthResponse, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, jenkins.NewJenkinsError("server health status is false")
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &healthResponse, nil
 }
 
 // GetRunningBuildsArgs defines the input parameters for jenkins_get_running_builds
@@ -458,24 +505,14 @@ type GetRunningBuildsArgs struct {
 }
 
 // handleGetRunningBuilds handles the jenkins_get_running_builds tool call
-func (s *Server) handleGetRunningBuilds(ctx context.Context, request *mcp.CallToolRequest, args GetRunningBuildsArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetRunningBuilds(ctx context.Context, request *mcp.CallToolRequest, args GetRunningBuildsArgs) (*mcp.CallToolResult, *RunningBuildsOutput, error) {
 	// Call Jenkins client
-	runningBuilds, err := s.jenkinsClient.GetRunningBuilds(ctx)
+	runningBuilds, err := s.client(ctx).GetRunningBuilds(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get running builds: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(runningBuilds, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &RunningBuildsOutput{Builds: runningBuilds}, nil
 }
 
 // GetQueueItemArgs defines the input parameters for jenkins_get_queue_item
@@ -484,24 +521,94 @@ type GetQueueItemArgs struct {
 }
 
 // handleGetQueueItem handles the jenkins_get_queue_item tool call
-func (s *Server) handleGetQueueItem(ctx context.Context, request *mcp.CallToolRequest, args GetQueueItemArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetQueueItem(ctx context.Context, request *mcp.CallToolRequest, args GetQueueItemArgs) (*mcp.CallToolResult, *jenkins.QueueItem, error) {
 	// Call Jenkins client
-	queueItem, err := s.jenkinsClient.GetQueueItem(ctx, args.QueueID)
+	queueItem, err := s.client(ctx).GetQueueItem(ctx, args.QueueID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get queue item: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(queueItem, "", "  ")
+	return nil, queueItem, nil
+}
+
+// WaitForBuildArgs defines the input parameters for jenkins_wait_for_build
+type WaitForBuildArgs struct {
+	JobName             string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
+	QueueID             int    `json:"queueId" jsonschema_description:"Queue item ID returned by jenkins_trigger_build"`
+	TimeoutSeconds      int    `json:"timeoutSeconds,omitempty" jsonschema_description:"Maximum time to wait in seconds (default 600)"`
+	PollIntervalSeconds int    `json:"pollIntervalSeconds,omitempty" jsonschema_description:"Polling interval in seconds (default 2)"`
+}
+
+// handleWaitForBuild handles the jenkins_wait_for_build tool call
+func (s *Server) handleWaitForBuild(ctx context.Context, request *mcp.CallToolRequest, args WaitForBuildArgs) (*mcp.CallToolResult, *jenkins.Build, error) {
+	opts := jenkins.WaitOptions{
+		Timeout:      time.Duration(args.TimeoutSeconds) * time.Second,
+		PollInterval: time.Duration(args.PollIntervalSeconds) * time.Second,
+	}
+
+	// Report progress to the client if it asked for it
+	if token := request.Params.GetProgressToken(); token != nil {
+		polls := 0
+		opts.OnProgress = func(progress jenkins.WaitProgress) {
+			polls++
+			err := request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      float64(polls),
+				Message:       waitProgressMessage(args.JobName, progress),
+			})
+			if err != nil {
+				s.log.WithFields(logrus.Fields{
+					"tool":  "jenkins_wait_for_build",
+					"error": err.Error(),
+				}).Debug("Failed to send progress notification")
+			}
+		}
+	}
+
+	// Call Jenkins client
+	build, err := s.client(ctx).WaitForBuild(ctx, args.JobName, args.QueueID, opts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		err = fmt.Errorf("failed to wait for build: %w", err)
+		if build == nil {
+			return nil, nil, err
+		}
+
+		// On timeout the build as last seen is reported with the error
+		result := toolErrorResult(err)
+		result.Content = append(result.Content, &mcp.TextContent{
+			Text: fmt.Sprintf("Build #%d was still running when the wait ended; call jenkins_wait_for_build again or use jenkins_get_build to follow it.", build.Number),
+		})
+		return result, build, nil
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	s.log.WithFields(logrus.Fields{
+		"tool":     "jenkins_wait_for_build",
+		"job":      args.JobName,
+		"queue_id": args.QueueID,
+		"build":    build.Number,
+		"result":   build.Result,
+	}).Info("Build completed")
+
+	return nil, build, nil
+}
+
+// waitProgressMessage renders a wait progress update as a short human-readable message
+func waitProgressMessage(jobName string, progress jenkins.WaitProgress) string {
+	elapsed := progress.Elapsed.Round(time.Second)
+	switch progress.Phase {
+	case jenkins.WaitPhaseQueued:
+		if progress.Why != "" {
+			return fmt.Sprintf("%s: queued for %s (%s)", jobName, elapsed, progress.Why)
+		}
+		return fmt.Sprintf("%s: queued for %s", jobName, elapsed)
+	case jenkins.WaitPhaseBuilding:
+		if progress.EstimatedDuration > 0 {
+			return fmt.Sprintf("%s #%d: building (estimated %s)", jobName, progress.BuildNumber, progress.EstimatedDuration.Round(time.Second))
+		}
+		return fmt.Sprintf("%s #%d: building", jobName, progress.BuildNumber)
+	default:
+		return fmt.Sprintf("%s #%d: completed after %s", jobName, progress.BuildNumber, elapsed)
+	}
 }
 
 // CancelQueueItemArgs defines the input parameters for jenkins_cancel_queue_item
@@ -512,7 +619,7 @@ type CancelQueueItemArgs struct {
 // handleCancelQueueItem handles the jenkins_cancel_queue_item tool call
 func (s *Server) handleCancelQueueItem(ctx context.Context, request *mcp.CallToolRequest, args CancelQueueItemArgs) (*mcp.CallToolResult, any, error) {
 	// Call Jenkins client
-	err := s.jenkinsClient.CancelQueueItem(ctx, args.QueueID)
+	err := s.client(ctx).CancelQueueItem(ctx, args.QueueID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to cancel queue item: %w", err)
 	}
@@ -533,24 +640,14 @@ type ListViewsArgs struct {
 }
 
 // handleListViews handles the jenkins_list_views tool call
-func (s *Server) handleListViews(ctx context.Context, request *mcp.CallToolRequest, args ListViewsArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleListViews(ctx context.Context, request *mcp.CallToolRequest, args ListViewsArgs) (*mcp.CallToolResult, *ViewListOutput, error) {
 	// Call Jenkins client
-	views, err := s.jenkinsClient.ListViews(ctx)
+	views, err := s.client(ctx).ListViews(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list views: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(views, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &ViewListOutput{Views: views}, nil
 }
 
 // GetViewArgs defines the input parameters for jenkins_get_view
@@ -559,24 +656,14 @@ type GetViewArgs struct {
 }
 
 // handleGetView handles the jenkins_get_view tool call
-func (s *Server) handleGetView(ctx context.Context, request *mcp.CallToolRequest, args GetViewArgs) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetView(ctx context.Context, request *mcp.CallToolRequest, args GetViewArgs) (*mcp.CallToolResult, *jenkins.ViewDetails, error) {
 	// Call Jenkins client
-	viewDetails, err := s.jenkinsClient.GetView(ctx, args.ViewName)
+	viewDetails, err := s.client(ctx).GetView(ctx, args.ViewName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get view: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(viewDetails, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, viewDetails, nil
 }
 
 // CreateViewArgs defines the input parameters for jenkins_create_view
@@ -588,7 +675,7 @@ type CreateViewArgs struct {
 // handleCreateView handles the jenkins_create_view tool call
 func (s *Server) handleCreateView(ctx context.Context, request *mcp.CallToolRequest, args CreateViewArgs) (*mcp.CallToolResult, any, error) {
 	// Call Jenkins client
-	err := s.jenkinsClient.CreateView(ctx, args.ViewName, args.ViewType)
+	err := s.client(ctx).CreateView(ctx, args.ViewName, args.ViewType)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create view: %w", err)
 	}
@@ -606,24 +693,14 @@ func (s *Server) handleCreateView(ctx context.Context, request *mcp.CallToolRequ
 type GetNodes struct{}
 
 // handleGetNodes handles the jenkins_get_nodes tool call
-func (s *Server) handleGetNodes(ctx context.Context, request *mcp.CallToolRequest, args GetNodes) (*mcp.CallToolResult, any, error) {
+func (s *Server) handleGetNodes(ctx context.Context, request *mcp.CallToolRequest, args GetNodes) (*mcp.CallToolResult, *NodeListOutput, error) {
 	// Call Jenkins client
-	nodes, err := s.jenkinsClient.GetNodes(ctx)
+	nodes, err := s.client(ctx).GetNodes(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
 
-	// Convert to JSON for response
-	result, err := json.MarshalIndent(nodes, "", "  ")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(result)},
-		},
-	}, nil, nil
+	return nil, &NodeListOutput{Nodes: nodes}, nil
 }
 
 type GetPipelineScriptArgs struct {
@@ -636,7 +713,7 @@ func (s *Server) handleGetPipelineScript(
 	args GetPipelineScriptArgs,
 ) (*mcp.CallToolResult, any, error) {
 
-	script, err := s.jenkinsClient.GetPipelineScript(ctx, args.Job)
+	script, err := s.client(ctx).GetPipelineScript(ctx, args.Job)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get pipeline script: %w", err)
 	}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListBuildsArgs defines the input parameters for jenkins_list_builds
+type ListBuildsArgs struct {
+	JobName    string            `json:"jobName" jsonschema_description:"Full name of the Jenkins job (e.g. team/service/main)"`
+	Result     string            `json:"result,omitempty" jsonschema_description:"Only builds with this result: SUCCESS, UNSTABLE, FAILURE, NOT_BUILT, ABORTED, or RUNNING for builds in progress"`
+	Since      string            `json:"since,omitempty" jsonschema_description:"Only builds started at or after this time (RFC 3339 or YYYY-MM-DD)"`
+	Until      string            `json:"until,omitempty" jsonschema_description:"Only builds started before this time (RFC 3339 or YYYY-MM-DD)"`
+	User       string            `json:"user,omitempty" jsonschema_description:"Only builds started by this user ID or name"`
+	Parameters map[string]string `json:"parameters,omitempty" jsonschema_description:"Only builds whose parameters have all of these values"`
+	Limit      int               `json:"limit,omitempty" jsonschema_description:"Maximum number of builds to return (default 20, max 100)"`
+	Cursor     string            `json:"cursor,omitempty" jsonschema_description:"nextCursor from a previous call to fetch the next page"`
+}
+
+// handleListBuilds handles the jenkins_list_builds tool call
+func (s *Server) handleListBuilds(ctx context.Context, request *mcp.CallToolRequest, args ListBuildsArgs) (*mcp.CallToolResult, *jenkins.BuildPage, error) {
+	since, err := parseTimeArgument("since", args.Since)
+	if err != nil {
+		return nil, nil, err
+	}
+	until, err := parseTimeArgument("until", args.Until)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Call Jenkins client
+	page, err := s.client(ctx).ListBuilds(ctx, args.JobName, jenkins.ListBuildsOptions{
+		Result:     args.Result,
+		Since:      since,
+		Until:      until,
+		User:       args.User,
+		Parameters: args.Parameters,
+		Limit:      args.Limit,
+		Cursor:     args.Cursor,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list builds: %w", err)
+	}
+
+	return nil, page, nil
+}
+
+// DiffBuildChangesArgs defines the input parameters for jenkins_diff_build_changes
+type DiffBuildChangesArgs struct {
+	JobName   string `json:"jobName" jsonschema_description:"Full name of the Jenkins job (e.g. team/service/main)"`
+	FromBuild int    `json:"fromBuild" jsonschema_description:"Earlier build number, e.g. the last good build; its own changes are not included"`
+	ToBuild   int    `json:"toBuild" jsonschema_description:"Later build number, e.g. the first failing build"`
+}
+
+// handleDiffBuildChanges handles the jenkins_diff_build_changes tool call
+func (s *Server) handleDiffBuildChanges(ctx context.Context, request *mcp.CallToolRequest, args DiffBuildChangesArgs) (*mcp.CallToolResult, *jenkins.ChangeDiff, error) {
+	// Call Jenkins client
+	diff, err := s.client(ctx).CompareChanges(ctx, args.JobName, args.FromBuild, args.ToBuild)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to diff build changes: %w", err)
+	}
+
+	return nil, diff, nil
+}
+
+// parseTimeArgument parses an RFC 3339 timestamp or a YYYY-MM-DD date (UTC midnight).
+// An empty value yields the zero time.
+func parseTimeArgument(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, jenkins.NewInvalidInputError(fmt.Sprintf("invalid %s %q: use RFC 3339 (2024-05-01T12:00:00Z) or YYYY-MM-DD", name, value))
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// DiagnoseBuildArgs defines the input parameters for jenkins_diagnose_build
+type DiagnoseBuildArgs struct {
+	JobName      string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
+	BuildNumber  *int   `json:"buildNumber,omitempty" jsonschema_description:"Build number (optional, omit to diagnose the latest build)"`
+	TailLines    int    `json:"tailLines,omitempty" jsonschema_description:"Number of lines from the end of the log to scan for errors (default 2000)"`
+	ContextLines *int   `json:"contextLines,omitempty" jsonschema_description:"Lines of context around each error marker; 0 keeps only the marker lines (default 3)"`
+}
+
+// handleDiagnoseBuild handles the jenkins_diagnose_build tool call
+func (s *Server) handleDiagnoseBuild(ctx context.Context, request *mcp.CallToolRequest, args DiagnoseBuildArgs) (*mcp.CallToolResult, *jenkins.BuildDiagnosis, error) {
+	buildNumber := 0
+	if args.BuildNumber != nil {
+		buildNumber = *args.BuildNumber
+	} else {
+		latest, err := s.client(ctx).GetLatestBuild(ctx, args.JobName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get latest build: %w", err)
+		}
+		buildNumber = latest.Number
+	}
+
+	// Call Jenkins client
+	diagnosis, err := s.client(ctx).DiagnoseBuild(ctx, args.JobName, buildNumber, jenkins.DiagnoseOptions{
+		TailLines:    args.TailLines,
+		ContextLines: args.ContextLines,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to diagnose build: %w", err)
+	}
+	for i := range diagnosis.LogExcerpts {
+		diagnosis.LogExcerpts[i].Text = redact.String(diagnosis.LogExcerpts[i].Text)
+	}
+	redactTestFailures(diagnosis.Tests)
+
+	s.log.WithFields(logrus.Fields{
+		"tool":     "jenkins_diagnose_build",
+		"job":      args.JobName,
+		"build":    buildNumber,
+		"result":   diagnosis.Build.Result,
+		"excerpts": len(diagnosis.LogExcerpts),
+	}).Debug("Diagnosed build")
+
+	return nil, diagnosis, nil
+}
@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListInstancesArgs defines the input parameters for jenkins_list_instances
+type ListInstancesArgs struct{}
+
+// InstanceInfo describes a configured Jenkins instance
+type InstanceInfo struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Default     bool   `json:"default"`
+	// Cache holds the response cache counters when caching is enabled
+	Cache *jenkins.CacheStats `json:"cache,omitempty"`
+}
+
+// handleListInstances handles the jenkins_list_instances tool call
+func (s *Server) handleListInstances(ctx context.Context, request *mcp.CallToolRequest, args ListInstancesArgs) (*mcp.CallToolResult, *InstanceListOutput, error) {
+	instances := make([]InstanceInfo, 0, len(s.instanceNames))
+	for _, name := range s.instanceNames {
+		instance := s.instances[name]
+		instances = append(instances, InstanceInfo{
+			Name:        instance.name,
+			URL:         instance.url,
+			Description: instance.description,
+			Default:     name == s.config.DefaultInstance(),
+			Cache:       instance.client.CacheStats(),
+		})
+	}
+
+	return nil, &InstanceListOutput{Instances: instances}, nil
+}
@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// GetJobConfigArgs defines the input parameters for jenkins_get_job_config
+type GetJobConfigArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the Jenkins job"`
+}
+
+// handleGetJobConfig handles the jenkins_get_job_config tool call
+func (s *Server) handleGetJobConfig(ctx context.Context, request *mcp.CallToolRequest, args GetJobConfigArgs) (*mcp.CallToolResult, any, error) {
+	// Call Jenkins client
+	configXML, err := s.client(ctx).GetJobConfig(ctx, args.JobName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get job config: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: configXML},
+		},
+	}, nil, nil
+}
+
+// CreateJobArgs defines the input parameters for jenkins_create_job
+type CreateJobArgs struct {
+	JobName   string `json:"jobName" jsonschema_description:"Full name of the new job; parent folders must already exist (e.g. team/new-job)"`
+	ConfigXML string `json:"configXml" jsonschema_description:"Job configuration as config.xml"`
+}
+
+// handleCreateJob handles the jenkins_create_job tool call
+func (s *Server) handleCreateJob(ctx context.Context, request *mcp.CallToolRequest, args CreateJobArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_create_job", args.JobName, "Creating job")
+
+	// Call Jenkins client
+	if err := s.client(ctx).CreateJob(ctx, args.JobName, args.ConfigXML); err != nil {
+		return nil, nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully created job '%s'", args.JobName)), nil, nil
+}
+
+// CopyJobArgs defines the input parameters for jenkins_copy_job
+type CopyJobArgs struct {
+	FromJob string `json:"fromJob" jsonschema_description:"Full name of the job to copy"`
+	ToJob   string `json:"toJob" jsonschema_description:"Full name of the new job"`
+}
+
+// handleCopyJob handles the jenkins_copy_job tool call
+func (s *Server) handleCopyJob(ctx context.Context, request *mcp.CallToolRequest, args CopyJobArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_copy_job", args.ToJob, "Copying job from "+args.FromJob)
+
+	// Call Jenkins client
+	if err := s.client(ctx).CopyJob(ctx, args.FromJob, args.ToJob); err != nil {
+		return nil, nil, fmt.Errorf("failed to copy job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully copied job '%s' to '%s'", args.FromJob, args.ToJob)), nil, nil
+}
+
+// UpdateJobConfigArgs defines the input parameters for jenkins_update_job_config
+type UpdateJobConfigArgs struct {
+	JobName   string `json:"jobName" jsonschema_description:"Full name of the Jenkins job"`
+	ConfigXML string `json:"configXml" jsonschema_description:"Complete replacement config.xml"`
+}
+
+// handleUpdateJobConfig handles the jenkins_update_job_config tool call
+func (s *Server) handleUpdateJobConfig(ctx context.Context, request *mcp.CallToolRequest, args UpdateJobConfigArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_update_job_config", args.JobName, "Updating job configuration")
+
+	// Call Jenkins client
+	if err := s.client(ctx).UpdateJobConfig(ctx, args.JobName, args.ConfigXML); err != nil {
+		return nil, nil, fmt.Errorf("failed to update job config: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully updated configuration of job '%s'", args.JobName)), nil, nil
+}
+
+// SetJobEnabledArgs defines the input parameters for jenkins_enable_job and jenkins_disable_job
+type SetJobEnabledArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the Jenkins job"`
+}
+
+// handleEnableJob handles the jenkins_enable_job tool call
+func (s *Server) handleEnableJob(ctx context.Context, request *mcp.CallToolRequest, args SetJobEnabledArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_enable_job", args.JobName, "Enabling job")
+
+	// Call Jenkins client
+	if err := s.client(ctx).SetJobEnabled(ctx, args.JobName, true); err != nil {
+		return nil, nil, fmt.Errorf("failed to enable job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully enabled job '%s'", args.JobName)), nil, nil
+}
+
+// handleDisableJob handles the jenkins_disable_job tool call
+func (s *Server) handleDisableJob(ctx context.Context, request *mcp.CallToolRequest, args SetJobEnabledArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_disable_job", args.JobName, "Disabling job")
+
+	// Call Jenkins client
+	if err := s.client(ctx).SetJobEnabled(ctx, args.JobName, false); err != nil {
+		return nil, nil, fmt.Errorf("failed to disable job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully disabled job '%s'", args.JobName)), nil, nil
+}
+
+// RenameJobArgs defines the input parameters for jenkins_rename_job
+type RenameJobArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the Jenkins job"`
+	NewName string `json:"newName" jsonschema_description:"New name for the job within the same folder"`
+}
+
+// handleRenameJob handles the jenkins_rename_job tool call
+func (s *Server) handleRenameJob(ctx context.Context, request *mcp.CallToolRequest, args RenameJobArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_rename_job", args.JobName, "Renaming job to "+args.NewName)
+
+	// Call Jenkins client
+	if err := s.client(ctx).RenameJob(ctx, args.JobName, args.NewName); err != nil {
+		return nil, nil, fmt.Errorf("failed to rename job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully renamed job '%s' to '%s'", args.JobName, args.NewName)), nil, nil
+}
+
+// DeleteJobArgs defines the input parameters for jenkins_delete_job
+type DeleteJobArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the Jenkins job to delete"`
+}
+
+// handleDeleteJob handles the jenkins_delete_job tool call
+func (s *Server) handleDeleteJob(ctx context.Context, request *mcp.CallToolRequest, args DeleteJobArgs) (*mcp.CallToolResult, any, error) {
+	s.logJobAction("jenkins_delete_job", args.JobName, "Deleting job")
+
+	// Call Jenkins client
+	if err := s.client(ctx).DeleteJob(ctx, args.JobName); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete job: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Successfully deleted job '%s'", args.JobName)), nil, nil
+}
+
+// logJobAction logs a job lifecycle change at Info level
+func (s *Server) logJobAction(tool, jobName, message string) {
+	s.log.WithFields(logrus.Fields{
+		"tool": tool,
+		"job":  jobName,
+	}).Info(message)
+}
+
+// jobActionResult wraps a success message in a tool result
+func jobActionResult(message string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: message},
+		},
+	}
+}
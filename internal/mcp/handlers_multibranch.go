@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// ListBranchesArgs defines the input parameters for jenkins_list_branches
+type ListBranchesArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the multibranch project (e.g. team/service)"`
+	Kind    string `json:"kind,omitempty" jsonschema_description:"Optional filter: branch, pull-request or tag"`
+}
+
+// handleListBranches handles the jenkins_list_branches tool call
+func (s *Server) handleListBranches(ctx context.Context, request *mcp.CallToolRequest, args ListBranchesArgs) (*mcp.CallToolResult, *BranchListOutput, error) {
+	// Call Jenkins client
+	branches, err := s.client(ctx).ListBranches(ctx, args.JobName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	// Apply the optional kind filter
+	if args.Kind != "" {
+		filtered := make([]jenkins.Branch, 0, len(branches))
+		for _, branch := range branches {
+			if branch.Kind == args.Kind {
+				filtered = append(filtered, branch)
+			}
+		}
+		branches = filtered
+	}
+
+	return nil, &BranchListOutput{Branches: branches}, nil
+}
+
+// ScanMultibranchArgs defines the input parameters for jenkins_scan_multibranch
+type ScanMultibranchArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the multibranch project or organization folder"`
+}
+
+// handleScanMultibranch handles the jenkins_scan_multibranch tool call
+func (s *Server) handleScanMultibranch(ctx context.Context, request *mcp.CallToolRequest, args ScanMultibranchArgs) (*mcp.CallToolResult, any, error) {
+	s.log.WithFields(logrus.Fields{
+		"tool": "jenkins_scan_multibranch",
+		"job":  args.JobName,
+	}).Info("Triggering branch scan")
+
+	// Call Jenkins client
+	if err := s.client(ctx).ScanMultibranch(ctx, args.JobName); err != nil {
+		return nil, nil, fmt.Errorf("failed to scan multibranch project: %w", err)
+	}
+
+	// Return success message
+	successMsg := fmt.Sprintf("Branch scan scheduled for '%s'", args.JobName)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: successMsg},
+		},
+	}, nil, nil
+}
+
+// GetBranchSourcesArgs defines the input parameters for jenkins_get_branch_sources
+type GetBranchSourcesArgs struct {
+	JobName string `json:"jobName" jsonschema_description:"Full name of the multibranch project or organization folder"`
+}
+
+// handleGetBranchSources handles the jenkins_get_branch_sources tool call
+func (s *Server) handleGetBranchSources(ctx context.Context, request *mcp.CallToolRequest, args GetBranchSourcesArgs) (*mcp.CallToolResult, *jenkins.MultibranchConfig, error) {
+	// Call Jenkins client
+	sources, err := s.client(ctx).GetBranchSources(ctx, args.JobName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get branch sources: %w", err)
+	}
+
+	return nil, sources, nil
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// GetNodeArgs defines the input parameters for jenkins_get_node
+type GetNodeArgs struct {
+	NodeName string `json:"nodeName" jsonschema_description:"Name of the node; use (built-in) for the controller's built-in node"`
+}
+
+// handleGetNode handles the jenkins_get_node tool call
+func (s *Server) handleGetNode(ctx context.Context, request *mcp.CallToolRequest, args GetNodeArgs) (*mcp.CallToolResult, *jenkins.Node, error) {
+	// Call Jenkins client
+	node, err := s.client(ctx).GetNode(ctx, args.NodeName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	return nil, node, nil
+}
+
+// TakeNodeOfflineArgs defines the input parameters for jenkins_take_node_offline
+type TakeNodeOfflineArgs struct {
+	NodeName string `json:"nodeName" jsonschema_description:"Name of the node to take offline"`
+	Reason   string `json:"reason" jsonschema_description:"Reason shown in Jenkins while the node is offline"`
+}
+
+// handleTakeNodeOffline handles the jenkins_take_node_offline tool call
+func (s *Server) handleTakeNodeOffline(ctx context.Context, request *mcp.CallToolRequest, args TakeNodeOfflineArgs) (*mcp.CallToolResult, any, error) {
+	if args.Reason == "" {
+		return nil, nil, jenkins.NewInvalidInputError("reason cannot be empty")
+	}
+
+	s.logNodeAction("jenkins_take_node_offline", args.NodeName, "Taking node offline")
+
+	// Call Jenkins client
+	if err := s.client(ctx).SetNodeOffline(ctx, args.NodeName, true, args.Reason); err != nil {
+		return nil, nil, fmt.Errorf("failed to take node offline: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Node '%s' is offline: %s. Running builds continue, but no new builds will start on it.", args.NodeName, args.Reason)), nil, nil
+}
+
+// BringNodeOnlineArgs defines the input parameters for jenkins_bring_node_online
+type BringNodeOnlineArgs struct {
+	NodeName string `json:"nodeName" jsonschema_description:"Name of the node to bring back online"`
+}
+
+// handleBringNodeOnline handles the jenkins_bring_node_online tool call
+func (s *Server) handleBringNodeOnline(ctx context.Context, request *mcp.CallToolRequest, args BringNodeOnlineArgs) (*mcp.CallToolResult, any, error) {
+	s.logNodeAction("jenkins_bring_node_online", args.NodeName, "Bringing node online")
+
+	// Call Jenkins client
+	if err := s.client(ctx).SetNodeOffline(ctx, args.NodeName, false, ""); err != nil {
+		return nil, nil, fmt.Errorf("failed to bring node online: %w", err)
+	}
+
+	return jobActionResult(fmt.Sprintf("Node '%s' is back online", args.NodeName)), nil, nil
+}
+
+// logNodeAction logs a node state change at Info level
+func (s *Server) logNodeAction(tool, nodeName, message string) {
+	s.log.WithFields(logrus.Fields{
+		"tool": tool,
+		"node": nodeName,
+	}).Info(message)
+}
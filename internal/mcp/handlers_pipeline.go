@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetPipelineStagesArgs defines the input parameters for jenkins_get_pipeline_stages
+type GetPipelineStagesArgs struct {
+	JobName     string `json:"jobName" jsonschema_description:"Name of the Jenkins pipeline job"`
+	BuildNumber int    `json:"buildNumber" jsonschema_description:"Build number"`
+}
+
+// handleGetPipelineStages handles the jenkins_get_pipeline_stages tool call
+func (s *Server) handleGetPipelineStages(ctx context.Context, request *mcp.CallToolRequest, args GetPipelineStagesArgs) (*mcp.CallToolResult, *jenkins.PipelineRun, error) {
+	// Call Jenkins client
+	run, err := s.client(ctx).GetPipelineRun(ctx, args.JobName, args.BuildNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pipeline stages: %w", err)
+	}
+
+	return nil, run, nil
+}
+
+// GetStageLogArgs defines the input parameters for jenkins_get_stage_log
+type GetStageLogArgs struct {
+	JobName     string `json:"jobName" jsonschema_description:"Name of the Jenkins pipeline job"`
+	BuildNumber int    `json:"buildNumber" jsonschema_description:"Build number"`
+	StageID     string `json:"stageId,omitempty" jsonschema_description:"Stage node ID from jenkins_get_pipeline_stages"`
+	StageName   string `json:"stageName,omitempty" jsonschema_description:"Stage name (used when stageId is omitted)"`
+}
+
+// handleGetStageLog handles the jenkins_get_stage_log tool call.
+// When neither stageId nor stageName is given the first failed stage is used.
+func (s *Server) handleGetStageLog(ctx context.Context, request *mcp.CallToolRequest, args GetStageLogArgs) (*mcp.CallToolResult, *jenkins.StageLog, error) {
+	stageID := args.StageID
+	if stageID == "" {
+		run, err := s.client(ctx).GetPipelineRun(ctx, args.JobName, args.BuildNumber)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get pipeline stages: %w", err)
+		}
+
+		stage, err := findStage(run, args.StageName)
+		if err != nil {
+			return nil, nil, err
+		}
+		stageID = stage.ID
+	}
+
+	// Call Jenkins client
+	stageLog, err := s.client(ctx).GetStageLog(ctx, args.JobName, args.BuildNumber, stageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get stage log: %w", err)
+	}
+	for i := range stageLog.Steps {
+		stageLog.Steps[i].Text = redact.String(stageLog.Steps[i].Text)
+	}
+
+	return nil, stageLog, nil
+}
+
+// findStage looks up a stage by name, or returns the first failed stage when name is empty
+func findStage(run *jenkins.PipelineRun, name string) (*jenkins.Stage, error) {
+	if name == "" {
+		if run.FailedStage == nil {
+			return nil, jenkins.NewNotFoundError(fmt.Sprintf("failed stage in build %s (specify stageId or stageName)", run.Name))
+		}
+		return run.FailedStage, nil
+	}
+
+	for i := range run.Stages {
+		if strings.EqualFold(run.Stages[i].Name, name) {
+			return &run.Stages[i], nil
+		}
+	}
+	return nil, jenkins.NewNotFoundError(fmt.Sprintf("stage %s", name))
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// RebuildArgs defines the input parameters for jenkins_rebuild
+type RebuildArgs struct {
+	JobName       string            `json:"jobName" jsonschema_description:"Full name of the Jenkins job (e.g. team/service/main)"`
+	BuildNumber   int               `json:"buildNumber" jsonschema_description:"Number of the build to run again"`
+	Parameters    map[string]any    `json:"parameters,omitempty" jsonschema_description:"Parameter values that replace those of the original build"`
+	Script        string            `json:"script,omitempty" jsonschema_description:"Modified main pipeline script; replays the build with it instead of re-triggering"`
+	LoadedScripts map[string]string `json:"loadedScripts,omitempty" jsonschema_description:"Modified loaded scripts by name, as returned by jenkins_get_replay_scripts; replays the build"`
+}
+
+// handleRebuild handles the jenkins_rebuild tool call. Without scripts the
+// build is re-triggered with its parameters and the overrides; with a script
+// the pipeline build is replayed.
+func (s *Server) handleRebuild(ctx context.Context, request *mcp.CallToolRequest, args RebuildArgs) (*mcp.CallToolResult, *jenkins.Rebuild, error) {
+	replay := args.Script != "" || len(args.LoadedScripts) > 0
+	if replay && len(args.Parameters) > 0 {
+		return nil, nil, jenkins.NewInvalidInputError("parameters cannot be changed when replaying a build; a replay reuses the parameters of the original build")
+	}
+
+	overrides, err := parameterValues(args.Parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tool":      "jenkins_rebuild",
+		"job":       args.JobName,
+		"build":     args.BuildNumber,
+		"replay":    replay,
+		"overrides": len(overrides),
+	}).Info("Rebuilding Jenkins build")
+
+	var rebuild *jenkins.Rebuild
+	if replay {
+		rebuild, err = s.client(ctx).ReplayBuild(ctx, args.JobName, args.BuildNumber, args.Script, args.LoadedScripts)
+	} else {
+		rebuild, err = s.client(ctx).RebuildBuild(ctx, args.JobName, args.BuildNumber, overrides)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rebuild build: %w", err)
+	}
+
+	// Convert to JSON for response
+	result, err := json.MarshalIndent(rebuild, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	var msg string
+	if rebuild.Replayed {
+		msg = fmt.Sprintf("✅ Build #%d replayed!\n\n%s\n\nThe replay is expected to run as build #%d; use jenkins_get_build to follow it.",
+			args.BuildNumber, string(result), rebuild.NextBuildNumber)
+	} else {
+		msg = fmt.Sprintf("✅ Build #%d triggered again!\n\n%s\n\nUse jenkins_wait_for_build with queueItem.id as queueId to follow the build to completion.",
+			args.BuildNumber, string(result))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: msg},
+		},
+	}, rebuild, nil
+}
+
+// GetReplayScriptsArgs defines the input parameters for jenkins_get_replay_scripts
+type GetReplayScriptsArgs struct {
+	JobName     string `json:"jobName" jsonschema_description:"Full name of the Jenkins pipeline job"`
+	BuildNumber int    `json:"buildNumber" jsonschema_description:"Build number"`
+}
+
+// handleGetReplayScripts handles the jenkins_get_replay_scripts tool call
+func (s *Server) handleGetReplayScripts(ctx context.Context, request *mcp.CallToolRequest, args GetReplayScriptsArgs) (*mcp.CallToolResult, *jenkins.ReplayScripts, error) {
+	// Call Jenkins client
+	scripts, err := s.client(ctx).GetReplayScripts(ctx, args.JobName, args.BuildNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get replay scripts: %w", err)
+	}
+
+	scripts.MainScript = redact.String(scripts.MainScript)
+	for name, text := range scripts.LoadedScripts {
+		scripts.LoadedScripts[name] = redact.String(text)
+	}
+
+	return nil, scripts, nil
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+)
+
+func TestWaitForBuildTimeoutReportsBuild(t *testing.T) {
+	session := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/queue/item/42/api/json":
+			w.Write([]byte(`{"id":42,"executable":{"number":9,"url":"http://jenkins/job/app/9/"}}`))
+		case "/job/app/9/api/json":
+			w.Write([]byte(`{"number":9,"building":true,"executor":{"number":0}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}), nil)
+
+	result := callTool(t, session, "jenkins_wait_for_build", map[string]any{
+		"jobName": "app", "queueId": 42, "timeoutSeconds": 1, "pollIntervalSeconds": 1,
+	})
+
+	if code := resultErrorCode(result); code != string(jenkins.ErrorCodeTimeout) {
+		t.Fatalf("error code = %q, want %s (%s)", code, jenkins.ErrorCodeTimeout, resultText(result))
+	}
+	data, _ := json.Marshal(result.StructuredContent)
+	var build jenkins.Build
+	if err := json.Unmarshal(data, &build); err != nil || build.Number != 9 || !build.Building {
+		t.Errorf("structured content = %s, want the running build #9", data)
+	}
+}
+
+func TestTestFailuresRedacted(t *testing.T) {
+	session := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/app/8/api/json":
+			w.Write([]byte(`{"number":8,"result":"UNSTABLE","building":false}`))
+		case "/job/app/8/testReport/api/json":
+			w.Write([]byte(`{"failCount":1,"suites":[{"name":"LoginTest","cases":[{"className":"LoginTest","name":"login",
+				"status":"FAILED","errorDetails":"login failed with password=hunter2-secret",
+				"errorStackTrace":"AssertionError: password=hunter2-secret\n\tat LoginTest.login"}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}), nil)
+
+	for _, tool := range []string{"jenkins_get_test_report", "jenkins_diagnose_build"} {
+		result := callTool(t, session, tool, map[string]any{"jobName": "app", "buildNumber": 8})
+		if code := resultErrorCode(result); code != "" {
+			t.Fatalf("%s failed: %s", tool, resultText(result))
+		}
+		data, _ := json.Marshal(result.StructuredContent)
+		if strings.Contains(string(data), "hunter2-secret") || !strings.Contains(string(data), "password=****") {
+			t.Errorf("%s returned %s, want the test failure secrets masked", tool, data)
+		}
+	}
+}
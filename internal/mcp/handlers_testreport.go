@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultFailedTestLimit is the number of failing tests returned when no limit is given
+const defaultFailedTestLimit = 10
+
+// GetTestReportArgs defines the input parameters for jenkins_get_test_report
+type GetTestReportArgs struct {
+	JobName     string `json:"jobName" jsonschema_description:"Name of the Jenkins job"`
+	BuildNumber int    `json:"buildNumber" jsonschema_description:"Build number"`
+	Filter      string `json:"filter,omitempty" jsonschema_description:"Which failing tests to list: failed (FAILED and REGRESSION, default) or regressed (REGRESSION only)"`
+	Limit       int    `json:"limit,omitempty" jsonschema_description:"Maximum number of failing tests to return (default 10)"`
+}
+
+// handleGetTestReport handles the jenkins_get_test_report tool call
+func (s *Server) handleGetTestReport(ctx context.Context, request *mcp.CallToolRequest, args GetTestReportArgs) (*mcp.CallToolResult, *jenkins.TestReportSummary, error) {
+	if args.Filter != "" && args.Filter != jenkins.TestFilterFailed && args.Filter != jenkins.TestFilterRegressed {
+		return nil, nil, jenkins.NewInvalidInputError(fmt.Sprintf("invalid filter %q: must be %s or %s", args.Filter, jenkins.TestFilterFailed, jenkins.TestFilterRegressed))
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultFailedTestLimit
+	}
+
+	// Call Jenkins client
+	report, err := s.client(ctx).GetTestReport(ctx, args.JobName, args.BuildNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get test report: %w", err)
+	}
+
+	summary := report.Summarize(args.Filter, limit)
+	redactTestFailures(summary)
+
+	return nil, summary, nil
+}
+
+// redactTestFailures masks secrets in the failure messages and stack traces of a test report summary
+func redactTestFailures(summary *jenkins.TestReportSummary) {
+	if summary == nil {
+		return
+	}
+	for i := range summary.FailedTests {
+		summary.FailedTests[i].ErrorDetails = redact.String(summary.FailedTests[i].ErrorDetails)
+		summary.FailedTests[i].ErrorStackTrace = redact.String(summary.FailedTests[i].ErrorStackTrace)
+	}
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// instanceArgument is the optional tool argument selecting a named Jenkins instance
+const instanceArgument = "instance"
+
+// instanceContextKey carries the Jenkins instance selected for a tool call
+type instanceContextKey struct{}
+
+// jenkinsInstance is a named connection to a Jenkins controller
+type jenkinsInstance struct {
+	name        string
+	url         string
+	description string
+	client      jenkins.JenkinsClient
+}
+
+// newInstances creates a Jenkins client for the default instance and every named instance
+func newInstances(cfg *config.Config) (map[string]*jenkinsInstance, []string, error) {
+	defaultClient, err := jenkins.NewClient(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Jenkins client: %w", err)
+	}
+
+	instances := map[string]*jenkinsInstance{
+		cfg.DefaultInstance(): {
+			name:   cfg.DefaultInstance(),
+			url:    cfg.JenkinsURL,
+			client: defaultClient,
+		},
+	}
+	names := []string{cfg.DefaultInstance()}
+
+	for _, inst := range cfg.Instances {
+		client, err := jenkins.NewClient(cfg.ForInstance(inst))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create Jenkins client for instance %q: %w", inst.Name, err)
+		}
+
+		instances[inst.Name] = &jenkinsInstance{
+			name:        inst.Name,
+			url:         inst.JenkinsURL,
+			description: inst.Description,
+			client:      client,
+		}
+		names = append(names, inst.Name)
+	}
+
+	return instances, names, nil
+}
+
+// withInstance returns a context that selects the given Jenkins instance
+func withInstance(ctx context.Context, instance *jenkinsInstance) context.Context {
+	return context.WithValue(ctx, instanceContextKey{}, instance)
+}
+
+// instance returns the Jenkins instance selected for the current tool call,
+// or the default instance when none was selected
+func (s *Server) instance(ctx context.Context) *jenkinsInstance {
+	if instance, ok := ctx.Value(instanceContextKey{}).(*jenkinsInstance); ok {
+		return instance
+	}
+	return s.instances[s.config.DefaultInstance()]
+}
+
+// client returns the Jenkins client for the current tool call
+func (s *Server) client(ctx context.Context) jenkins.JenkinsClient {
+	return s.instance(ctx).client
+}
+
+// resolveInstance looks up the instance named by the call's instance argument
+func (s *Server) resolveInstance(request *mcp.CallToolRequest) (*jenkinsInstance, error) {
+	var args struct {
+		Instance string `json:"instance"`
+	}
+	if request != nil && request.Params != nil && len(request.Params.Arguments) > 0 {
+		// Malformed arguments are reported by the SDK's own validation
+		_ = json.Unmarshal(request.Params.Arguments, &args)
+	}
+
+	if args.Instance == "" {
+		return s.instances[s.config.DefaultInstance()], nil
+	}
+
+	instance, ok := s.instances[args.Instance]
+	if !ok {
+		return nil, jenkins.NewInvalidInputError(fmt.Sprintf("unknown Jenkins instance %q: must be one of %s",
+			args.Instance, strings.Join(s.instanceNames, ", ")))
+	}
+
+	return instance, nil
+}
+
+// instanceSchema describes the instance argument added to every tool
+func (s *Server) instanceSchema() *jsonschema.Schema {
+	enum := make([]any, len(s.instanceNames))
+	for i, name := range s.instanceNames {
+		enum[i] = name
+	}
+
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: fmt.Sprintf("Jenkins instance to use (default: %s). See jenkins_list_instances.", s.config.DefaultInstance()),
+		Enum:        enum,
+	}
+}
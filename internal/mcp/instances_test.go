@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// instanceJenkins serves every job as a job named after the instance and
+// queue item 1 as a build of job <instance>/app
+func instanceJenkins(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/queue/item/1/api/json":
+			fmt.Fprintf(w, `{"id":1,"task":{"name":"app","url":"http://jenkins/job/%s/job/app/"}}`, name)
+		case r.Method == http.MethodPost && r.URL.Path == "/queue/cancelItem":
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/job/"):
+			fmt.Fprintf(w, `{"name":%q,"fullName":%q,"buildable":true}`, name, name)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestInstanceRouting(t *testing.T) {
+	staging := httptest.NewServer(instanceJenkins("staging"))
+	t.Cleanup(staging.Close)
+
+	auditFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	session := newTestSession(t, instanceJenkins("production"), func(cfg *config.Config) {
+		cfg.Instances = []config.InstanceConfig{{Name: "staging", JenkinsURL: staging.URL, Username: "admin", Password: "secret"}}
+		cfg.Policy.JobRestrictions = map[string][]string{"jenkins_cancel_queue_item": {"staging/**"}}
+		cfg.Audit.File = auditFile
+	})
+
+	jobTests := []struct {
+		name    string
+		args    map[string]any
+		wantJob string
+	}{
+		{name: "default instance", args: map[string]any{"jobName": "app"}, wantJob: "production"},
+		{name: "named instance", args: map[string]any{"jobName": "app", "instance": "staging"}, wantJob: "staging"},
+	}
+	for _, tt := range jobTests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, session, "jenkins_get_job", tt.args)
+			if !strings.Contains(resultText(result), `"name":"`+tt.wantJob+`"`) {
+				t.Errorf("result = %s, want the job of instance %s", resultText(result), tt.wantJob)
+			}
+		})
+	}
+
+	// The instance argument only accepts configured instances
+	_, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "jenkins_get_job",
+		Arguments: map[string]any{"jobName": "app", "instance": "qa"},
+	})
+	if err == nil {
+		t.Error("call naming an unknown instance succeeded")
+	}
+
+	// Queue items are resolved to their job on the instance the call selects
+	if code := resultErrorCode(callTool(t, session, "jenkins_cancel_queue_item", map[string]any{"queueId": 1, "instance": "staging"})); code != "" {
+		t.Errorf("cancelling a staging queue item on staging error code = %q, want success", code)
+	}
+	if code := resultErrorCode(callTool(t, session, "jenkins_cancel_queue_item", map[string]any{"queueId": 1})); code != string(jenkins.ErrorCodePermissionDenied) {
+		t.Errorf("cancelling a production queue item error code = %q, want %s", code, jenkins.ErrorCodePermissionDenied)
+	}
+
+	data, err := os.ReadFile(auditFile)
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	events := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(events) != 2 || !strings.Contains(events[0], `"instance":"staging"`) || !strings.Contains(events[1], `"instance":"default"`) {
+		t.Errorf("audit events = %v, want the staging and then the default instance", events)
+	}
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"reflect"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Structured tool outputs. MCP requires structured content to be a JSON
+// object, so list results are wrapped in a named field.
+
+// JobListOutput is the structured result of jenkins_list_jobs
+type JobListOutput struct {
+	Jobs []jenkins.Job `json:"jobs"`
+}
+
+// ArtifactListOutput is the structured result of jenkins_list_artifacts
+type ArtifactListOutput struct {
+	Artifacts []jenkins.Artifact `json:"artifacts"`
+}
+
+// ArtifactContentOutput is the structured result of jenkins_get_artifact
+type ArtifactContentOutput struct {
+	ArtifactPath string `json:"artifactPath"`
+	Size         int    `json:"size"`
+	Encoding     string `json:"encoding"`
+	Content      string `json:"content"`
+}
+
+// QueueOutput is the structured result of jenkins_get_queue
+type QueueOutput struct {
+	Items []jenkins.QueueItem `json:"items"`
+}
+
+// RunningBuildsOutput is the structured result of jenkins_get_running_builds
+type RunningBuildsOutput struct {
+	Builds []jenkins.RunningBuild `json:"builds"`
+}
+
+// StopBuildOutput is the structured result of jenkins_stop_build
+type StopBuildOutput struct {
+	Success     bool   `json:"success"`
+	Message     string `json:"message"`
+	JobName     string `json:"jobName"`
+	BuildNumber int    `json:"buildNumber"`
+}
+
+// ViewListOutput is the structured result of jenkins_list_views
+type ViewListOutput struct {
+	Views []jenkins.View `json:"views"`
+}
+
+// NodeListOutput is the structured result of jenkins_list_nodes
+type NodeListOutput struct {
+	Nodes []jenkins.Node `json:"nodes"`
+}
+
+// BranchListOutput is the structured result of jenkins_list_branches
+type BranchListOutput struct {
+	Branches []jenkins.Branch `json:"branches"`
+}
+
+// InstanceListOutput is the structured result of jenkins_list_instances
+type InstanceListOutput struct {
+	Instances []InstanceInfo `json:"instances"`
+}
+
+// ServerHealthOutput is the structured result of jenkins_server_health
+type ServerHealthOutput struct {
+	Status bool `json:"status"`
+}
+
+// outputSchema infers the published output schema of a tool from its Go
+// result type. Go encodes nil slices and maps as null, so every array and
+// object-valued map in the schema also accepts null.
+func outputSchema[Out any]() (*jsonschema.Schema, error) {
+	t := reflect.TypeFor[Out]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	allowNullCollections(schema)
+	return schema, nil
+}
+
+// allowNullCollections walks a schema and lets array and map values be null
+func allowNullCollections(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+
+	switch {
+	case schema.Type == "array":
+		schema.Types = []string{"null", "array"}
+		schema.Type = ""
+	case schema.Type == "object" && schema.Properties == nil && schema.AdditionalProperties != nil:
+		schema.Types = []string{"null", "object"}
+		schema.Type = ""
+	}
+
+	for _, property := range schema.Properties {
+		allowNullCollections(property)
+	}
+	allowNullCollections(schema.Items)
+	allowNullCollections(schema.AdditionalProperties)
+}
+
+// structuredOutput converts a typed handler result to structured content,
+// treating a nil pointer as "no structured content"
+func structuredOutput(out any) any {
+	if out == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(out); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	return out
+}
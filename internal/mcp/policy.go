@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// jobArgumentKeys are the tool argument names that carry a job's full name
+var jobArgumentKeys = []string{"jobName", "job", "fromJob", "toJob"}
+
+// Policy decides which tools are exposed and which jobs they may act on
+type Policy struct {
+	cfg config.PolicyConfig
+}
+
+// NewPolicy creates a policy from configuration
+func NewPolicy(cfg config.PolicyConfig) *Policy {
+	return &Policy{cfg: cfg}
+}
+
+// AllowTool reports whether a tool may be registered and called.
+// It returns a permission error describing the rule that excludes the tool.
+func (p *Policy) AllowTool(tool *mcp.Tool) error {
+	if p.cfg.ReadOnly && !isReadOnly(tool) {
+		return jenkins.NewPermissionDeniedError(fmt.Sprintf("tool %s modifies Jenkins and the server is in read-only mode", tool.Name))
+	}
+
+	if pattern, ok := matchAny(p.cfg.DeniedTools, tool.Name); ok {
+		return jenkins.NewPermissionDeniedError(fmt.Sprintf("tool %s is denied by policy pattern %q", tool.Name, pattern))
+	}
+
+	if len(p.cfg.AllowedTools) > 0 {
+		if _, ok := matchAny(p.cfg.AllowedTools, tool.Name); !ok {
+			return jenkins.NewPermissionDeniedError(fmt.Sprintf("tool %s is not in the allowed tools list", tool.Name))
+		}
+	}
+
+	return nil
+}
+
+// AllowJob reports whether a tool may act on the given job.
+// Every job restriction whose tool pattern matches the tool must allow the job.
+func (p *Policy) AllowJob(toolName, jobName string) error {
+	for toolPattern, jobPatterns := range p.cfg.JobRestrictions {
+		if matched, _ := path.Match(toolPattern, toolName); !matched {
+			continue
+		}
+
+		allowed := false
+		for _, jobPattern := range jobPatterns {
+			if matchJob(jobPattern, jobName) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return jenkins.NewPermissionDeniedError(fmt.Sprintf("tool %s is not allowed to act on job %s", toolName, jobName))
+		}
+	}
+
+	return nil
+}
+
+// CheckCall enforces the policy for a single tool invocation. A call of a
+// restricted tool that names no job but a queue item is checked against the
+// job the queue item builds, looked up through client; mutating calls that
+// name neither are denied, as the jobs they affect cannot be determined.
+func (p *Policy) CheckCall(ctx context.Context, tool *mcp.Tool, request *mcp.CallToolRequest, client jenkins.JenkinsClient) error {
+	if err := p.AllowTool(tool); err != nil {
+		return err
+	}
+
+	if !p.restricts(tool.Name) {
+		return nil
+	}
+
+	var raw json.RawMessage
+	if request != nil && request.Params != nil {
+		raw = request.Params.Arguments
+	}
+
+	jobNames := jobNamesFromArguments(raw)
+	if len(jobNames) == 0 {
+		if queueID, ok := queueIDFromArguments(raw); ok {
+			item, err := client.GetQueueItem(ctx, queueID)
+			if err != nil {
+				return fmt.Errorf("failed to resolve the job of queue item %d: %w", queueID, err)
+			}
+			jobNames = append(jobNames, item.JobName)
+		}
+	}
+
+	if len(jobNames) == 0 && !isReadOnly(tool) {
+		return jenkins.NewPermissionDeniedError(fmt.Sprintf("tool %s is restricted to certain jobs and the call names no job", tool.Name))
+	}
+
+	for _, jobName := range jobNames {
+		if err := p.AllowJob(tool.Name, jobName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restricts reports whether any job restriction applies to a tool
+func (p *Policy) restricts(toolName string) bool {
+	for toolPattern := range p.cfg.JobRestrictions {
+		if matched, _ := path.Match(toolPattern, toolName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// queueIDFromArguments extracts the queue item a tool call refers to
+func queueIDFromArguments(raw json.RawMessage) (int, bool) {
+	var args struct {
+		QueueID int `json:"queueId"`
+	}
+	if len(raw) == 0 || json.Unmarshal(raw, &args) != nil || args.QueueID <= 0 {
+		return 0, false
+	}
+	return args.QueueID, true
+}
+
+// jobNamesFromArguments extracts the job names a tool call refers to.
+// A rename target is expanded to its full name within the source job's folder.
+func jobNamesFromArguments(raw json.RawMessage) []string {
+	var args map[string]any
+	if len(raw) == 0 || json.Unmarshal(raw, &args) != nil {
+		return nil
+	}
+
+	var names []string
+	for _, key := range jobArgumentKeys {
+		if name, ok := args[key].(string); ok && name != "" {
+			names = append(names, strings.Trim(name, "/"))
+		}
+	}
+
+	if newName, ok := args["newName"].(string); ok && newName != "" {
+		if jobName, ok := args["jobName"].(string); ok {
+			if i := strings.LastIndex(strings.Trim(jobName, "/"), "/"); i >= 0 {
+				newName = strings.Trim(jobName, "/")[:i+1] + newName
+			}
+		}
+		names = append(names, newName)
+	}
+
+	return names
+}
+
+// matchJob matches a job full name against a glob. A trailing "/**" matches
+// the folder itself and everything nested below it; "*" and "**" alone match any job.
+func matchJob(pattern, jobName string) bool {
+	if pattern == "*" || pattern == "**" {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		if matchJob(prefix, jobName) {
+			return true
+		}
+		segments := strings.Split(jobName, "/")
+		for i := 1; i < len(segments); i++ {
+			if matchJob(prefix, strings.Join(segments[:i], "/")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	matched, _ := path.Match(pattern, jobName)
+	return matched
+}
+
+// matchAny returns the first pattern that matches name
+func matchAny(patterns []string, name string) (string, bool) {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// isReadOnly reports whether a tool is annotated as not modifying Jenkins
+func isReadOnly(tool *mcp.Tool) bool {
+	return tool.Annotations != nil && tool.Annotations.ReadOnlyHint
+}
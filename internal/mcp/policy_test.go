@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestMatchJob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		jobName string
+		want    bool
+	}{
+		{pattern: "*", jobName: "team-a/app", want: true},
+		{pattern: "**", jobName: "team-a/app", want: true},
+		{pattern: "app", jobName: "app", want: true},
+		{pattern: "app", jobName: "app-2", want: false},
+		{pattern: "app-*", jobName: "app-2", want: true},
+		{pattern: "team-a/*", jobName: "team-a/app", want: true},
+		{pattern: "team-a/*", jobName: "team-a/sub/app", want: false},
+		{pattern: "team-a/*", jobName: "team-a", want: false},
+		{pattern: "team-a/**", jobName: "team-a", want: true},
+		{pattern: "team-a/**", jobName: "team-a/app", want: true},
+		{pattern: "team-a/**", jobName: "team-a/sub/app", want: true},
+		{pattern: "team-a/**", jobName: "team-ab/app", want: false},
+		{pattern: "team-*/**", jobName: "team-b/sub/app", want: true},
+		{pattern: "*/deploy", jobName: "team-a/deploy", want: true},
+		{pattern: "*/deploy", jobName: "team-a/sub/deploy", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.jobName, func(t *testing.T) {
+			if got := matchJob(tt.pattern, tt.jobName); got != tt.want {
+				t.Errorf("matchJob(%q, %q) = %v, want %v", tt.pattern, tt.jobName, got, tt.want)
+			}
+		})
+	}
+}
+
+// policyJenkins serves queue items 1 (job sandbox/app) and 2 (job prod/app)
+// and accepts any change, counting the requests that change Jenkins
+func policyJenkins(mutations *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/job/"):
+			mutations.Add(1)
+			w.Header().Set("Location", "/queue/item/3/")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost:
+			mutations.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/queue/item/1/api/json":
+			fmt.Fprint(w, `{"id":1,"task":{"name":"app","url":"http://jenkins/job/sandbox/job/app/"}}`)
+		case r.URL.Path == "/queue/item/2/api/json":
+			fmt.Fprint(w, `{"id":2,"task":{"name":"app","url":"http://jenkins/job/prod/job/app/"}}`)
+		case r.URL.Path == "/crumbIssuer/api/json", strings.HasPrefix(r.URL.Path, "/queue/item/"):
+			http.NotFound(w, r)
+		default:
+			fmt.Fprint(w, `{"name":"app","buildable":true,"property":[]}`)
+		}
+	})
+}
+
+func TestPolicyJobRestrictions(t *testing.T) {
+	var mutations atomic.Int32
+	session := newTestSession(t, policyJenkins(&mutations), func(cfg *config.Config) {
+		cfg.Policy.JobRestrictions = map[string][]string{"jenkins_*": {"sandbox/**"}}
+	})
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     map[string]any
+		wantCode string
+	}{
+		{name: "allowed job", tool: "jenkins_trigger_build", args: map[string]any{"jobName": "sandbox/app"}},
+		{name: "restricted job", tool: "jenkins_trigger_build", args: map[string]any{"jobName": "prod/app"},
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+		{name: "copy into a restricted folder", tool: "jenkins_copy_job", args: map[string]any{"fromJob": "sandbox/app", "toJob": "prod/app"},
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+		{name: "queue item of an allowed job", tool: "jenkins_cancel_queue_item", args: map[string]any{"queueId": 1}},
+		{name: "queue item of a restricted job", tool: "jenkins_cancel_queue_item", args: map[string]any{"queueId": 2},
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+		{name: "unknown queue item", tool: "jenkins_cancel_queue_item", args: map[string]any{"queueId": 9},
+			wantCode: string(jenkins.ErrorCodeNotFound)},
+		{name: "mutating call naming no job", tool: "jenkins_take_node_offline", args: map[string]any{"nodeName": "agent-1", "reason": "maintenance"},
+			wantCode: string(jenkins.ErrorCodePermissionDenied)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := mutations.Load()
+			result := callTool(t, session, tt.tool, tt.args)
+
+			if code := resultErrorCode(result); code != tt.wantCode {
+				t.Fatalf("%s error code = %q, want %q (%s)", tt.tool, code, tt.wantCode, resultText(result))
+			}
+			if changed := mutations.Load() != before; changed != (tt.wantCode == "") {
+				t.Errorf("%s changed Jenkins = %v, want %v", tt.tool, changed, tt.wantCode == "")
+			}
+		})
+	}
+}
+
+func TestPolicyToolFilters(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     config.PolicyConfig
+		wantTool   string
+		hiddenTool string
+	}{
+		{name: "read-only", policy: config.PolicyConfig{ReadOnly: true},
+			wantTool: "jenkins_get_job", hiddenTool: "jenkins_trigger_build"},
+		{name: "allowed tools", policy: config.PolicyConfig{AllowedTools: []string{"jenkins_get_*"}},
+			wantTool: "jenkins_get_build", hiddenTool: "jenkins_list_jobs"},
+		{name: "denied tools win", policy: config.PolicyConfig{AllowedTools: []string{"jenkins_*"}, DeniedTools: []string{"jenkins_delete_*"}},
+			wantTool: "jenkins_trigger_build", hiddenTool: "jenkins_delete_job"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newTestSession(t, http.NotFoundHandler(), func(cfg *config.Config) {
+				cfg.Policy = tt.policy
+			})
+
+			tools, err := session.ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListTools() error = %v", err)
+			}
+			registered := make(map[string]bool)
+			for _, tool := range tools.Tools {
+				registered[tool.Name] = true
+			}
+			if !registered[tt.wantTool] || registered[tt.hiddenTool] {
+				t.Errorf("%s registered = %v, %s registered = %v, want true, false",
+					tt.wantTool, registered[tt.wantTool], tt.hiddenTool, registered[tt.hiddenTool])
+			}
+
+			// Hidden tools cannot be called either
+			if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: tt.hiddenTool, Arguments: map[string]any{}}); err == nil {
+				t.Errorf("calling hidden tool %s succeeded", tt.hiddenTool)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
+	"github.com/NithishNithi/go-jenkins-mcp/internal/audit"
 	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
-	"github.com/NithishNithi/go-jenkins-mcp/internal/jenkins"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 )
@@ -15,15 +17,26 @@ type Server struct {
 	config        *config.Config
 	log           *logrus.Logger
 	mcpServer     *mcp.Server
-	jenkinsClient jenkins.JenkinsClient
+	instances     map[string]*jenkinsInstance
+	instanceNames []string
+	policy        *Policy
+	confirmer     *Confirmer
+	audit         *audit.Logger
+	toolCount     int
 }
 
 // NewServer creates a new MCP server instance
 func NewServer(cfg *config.Config, log *logrus.Logger) (*Server, error) {
-	// Create Jenkins client
-	jenkinsClient, err := jenkins.NewClient(cfg)
+	// Create a Jenkins client for every configured instance
+	instances, instanceNames, err := newInstances(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Jenkins client: %w", err)
+		return nil, err
+	}
+
+	// Open the audit log of mutating tool calls
+	auditLog, err := audit.New(cfg.Audit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
 	}
 
 	// Create MCP server with implementation info
@@ -36,26 +49,62 @@ func NewServer(cfg *config.Config, log *logrus.Logger) (*Server, error) {
 		config:        cfg,
 		log:           log,
 		mcpServer:     mcpServer,
-		jenkinsClient: jenkinsClient,
+		instances:     instances,
+		instanceNames: instanceNames,
+		policy:        NewPolicy(cfg.Policy),
+		confirmer:     NewConfirmer(cfg.Confirmation),
+		audit:         auditLog,
 	}
 
 	// Register all tools
 	if err := server.registerTools(); err != nil {
+		auditLog.Close()
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
 
 	return server, nil
 }
 
-// Start starts the MCP server with stdio communication
+// Start starts the MCP server using the configured transport.
+// It blocks until ctx is cancelled or the transport fails.
 func (s *Server) Start(ctx context.Context) error {
+	if s.config.Metrics.Enabled {
+		stopMetrics, err := s.startMetrics()
+		if err != nil {
+			return err
+		}
+		defer stopMetrics()
+	}
+
+	switch s.config.Transport {
+	case config.TransportSSE:
+		return s.serveHTTP(ctx, mcp.NewSSEHandler(func(*http.Request) *mcp.Server {
+			return s.mcpServer
+		}, nil))
+	case config.TransportStreamableHTTP:
+		return s.serveHTTP(ctx, mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+			return s.mcpServer
+		}, nil))
+	default:
+		return s.serveStdio(ctx)
+	}
+}
+
+// Close releases the resources held by the server, such as the audit log file
+func (s *Server) Close() error {
+	return s.audit.Close()
+}
+
+// serveStdio runs the MCP server over stdin/stdout
+func (s *Server) serveStdio(ctx context.Context) error {
 	s.log.WithFields(logrus.Fields{
-		"transport":   "stdio",
+		"transport":   config.TransportStdio,
 		"jenkins_url": s.config.JenkinsURL,
+		"instances":   s.instanceNames,
 	}).Info("Starting Jenkins MCP Server")
 
 	// Start the server with stdio transport
-	if err := s.mcpServer.Run(ctx, &mcp.StdioTransport{}); err != nil {
+	if err := s.mcpServer.Run(ctx, &mcp.StdioTransport{}); err != nil && !errors.Is(err, context.Canceled) {
 		s.log.WithError(err).Error("MCP server failed")
 		return fmt.Errorf("MCP server failed: %w", err)
 	}
@@ -70,114 +119,295 @@ func (s *Server) registerTools() error {
 	// ───────────────────────────────
 	// JOBS
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_job",
 		Description: "Get detailed information about a specific Jenkins job including configuration, parameters, and recent build history.",
+		Annotations: readOnlyTool,
 	}, s.handleGetJob)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_list_jobs",
-		Description: "List all accessible Jenkins jobs. Optionally filter by folder path.",
+		Description: "List all accessible Jenkins jobs. Optionally filter by folder path (e.g. team/service) and recurse into nested folders.",
+		Annotations: readOnlyTool,
 	}, s.handleListJobs)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_trigger_build",
-		Description: "Trigger a new build for a Jenkins job. Supports parameterized builds.",
+		Description: "Trigger a new build for a Jenkins job. Supports parameterized builds: values are checked against the job's parameter definitions (booleans, choices), and file parameters take base64-encoded files.",
+		Annotations: mutatingTool,
 	}, s.handleTriggerBuild)
 
+	// ───────────────────────────────
+	// JOB CONFIGURATION
+	// ───────────────────────────────
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_job_config",
+		Description: "Get the raw config.xml of a Jenkins job.",
+		Annotations: readOnlyTool,
+	}, s.handleGetJobConfig)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_create_job",
+		Description: "Create a new Jenkins job from a config.xml document.",
+		Annotations: mutatingTool,
+	}, s.handleCreateJob)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_copy_job",
+		Description: "Create a new Jenkins job as a copy of an existing job.",
+		Annotations: mutatingTool,
+	}, s.handleCopyJob)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_update_job_config",
+		Description: "Replace the config.xml of an existing Jenkins job.",
+		Annotations: destructiveTool,
+	}, s.handleUpdateJobConfig)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_enable_job",
+		Description: "Enable a disabled Jenkins job.",
+		Annotations: mutatingTool,
+	}, s.handleEnableJob)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_disable_job",
+		Description: "Disable a Jenkins job so it cannot be built.",
+		Annotations: destructiveTool,
+	}, s.handleDisableJob)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_rename_job",
+		Description: "Rename a Jenkins job within its current folder.",
+		Annotations: destructiveTool,
+	}, s.handleRenameJob)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_delete_job",
+		Description: "Permanently delete a Jenkins job and all of its builds.",
+		Annotations: destructiveTool,
+	}, s.handleDeleteJob)
+
+	// ───────────────────────────────
+	// MULTIBRANCH
+	// ───────────────────────────────
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_list_branches",
+		Description: "List the branch, pull request and tag jobs of a multibranch pipeline with the latest build of each. Use this to answer questions like \"is PR 123 green?\".",
+		Annotations: readOnlyTool,
+	}, s.handleListBranches)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_scan_multibranch",
+		Description: "Trigger a branch scan (reindex) of a multibranch pipeline or organization folder.",
+		Annotations: mutatingTool,
+	}, s.handleScanMultibranch)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_branch_sources",
+		Description: "Get the branch source (SCM) configuration of a multibranch pipeline or organization folder.",
+		Annotations: readOnlyTool,
+	}, s.handleGetBranchSources)
+
 	// ───────────────────────────────
 	// BUILDS
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_build",
-		Description: "Get status and details of a specific build. If buildNumber is omitted, returns the latest build.",
+		Description: "Get status and details of a specific build, including what triggered it (user, SCM, upstream build or timer), the commits it picked up, its culprits and the SCM revisions it checked out. If buildNumber is omitted, returns the latest build.",
+		Annotations: readOnlyTool,
 	}, s.handleGetBuild)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_diff_build_changes",
+		Description: "List the commits that went into a job between two builds, e.g. between the last good and the first failing build, grouped by the build that picked them up, with their authors and the SCM revisions of both builds.",
+		Annotations: readOnlyTool,
+	}, s.handleDiffBuildChanges)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_list_builds",
+		Description: "List a job's build history, newest first. Filter by result, start date range, triggering user and parameter values. Returns nextCursor when more builds may match; pass it back as cursor to get the next page.",
+		Annotations: readOnlyTool,
+	}, s.handleListBuilds)
+
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_build_log",
 		Description: "Retrieve the console output (log) for a specific build. Supports optional size limits for large logs.",
+		Annotations: readOnlyTool,
 	}, s.handleGetBuildLog)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_build_log_progressive",
+		Description: "Read a build's console log from a byte offset, or only its last N lines. Returns at most 1 MiB per call, with nextStart and moreData so a long log or a running build can be read incrementally.",
+		Annotations: readOnlyTool,
+	}, s.handleGetBuildLogProgressive)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_diagnose_build",
+		Description: "Explain why a build failed. Returns the build result, the failing pipeline stage, failing tests, and console log excerpts around error markers with line numbers. Prefer this over jenkins_get_build_log for failure triage.",
+		Annotations: readOnlyTool,
+	}, s.handleDiagnoseBuild)
+
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_running_builds",
-		Description: "Get all currently running builds across all Jenkins jobs.",
+		Description: "Get all currently running builds across all Jenkins jobs and folders, with the node and executor running each build, its progress percentage and elapsed time.",
+		Annotations: readOnlyTool,
 	}, s.handleGetRunningBuilds)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_stop_build",
 		Description: "Stop a running build. The build status will be updated to ABORTED.",
+		Annotations: destructiveTool,
 	}, s.handleStopBuild)
 
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_rebuild",
+		Description: "Run a previous build again with the same parameters, optionally overriding some of them. Passing a modified pipeline script (see jenkins_get_replay_scripts) replays the build with that script instead.",
+		Annotations: mutatingTool,
+	}, s.handleRebuild)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_replay_scripts",
+		Description: "Get the main pipeline script and loaded scripts a pipeline build ran with, as offered for replay. Edit them and pass them to jenkins_rebuild to replay the build; masked secrets on lines left unchanged are restored when replaying.",
+		Annotations: readOnlyTool,
+	}, s.handleGetReplayScripts)
+
+	// ───────────────────────────────
+	// PIPELINE STAGES
+	// ───────────────────────────────
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_pipeline_stages",
+		Description: "List the stages of a pipeline build with status and duration, and identify the first failed stage.",
+		Annotations: readOnlyTool,
+	}, s.handleGetPipelineStages)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_stage_log",
+		Description: "Get the console output of a single pipeline stage by ID or name. Defaults to the first failed stage.",
+		Annotations: readOnlyTool,
+	}, s.handleGetStageLog)
+
+	// ───────────────────────────────
+	// TEST RESULTS
+	// ───────────────────────────────
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_test_report",
+		Description: "Get a summary of a build's JUnit test results: pass/fail/skip counts and the top failing tests with error details and stack traces. Can be filtered to regressions only.",
+		Annotations: readOnlyTool,
+	}, s.handleGetTestReport)
+
 	// ───────────────────────────────
 	// ARTIFACTS
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_artifact",
 		Description: "Download a specific artifact from a build. Returns the artifact content.",
+		Annotations: readOnlyTool,
 	}, s.handleGetArtifact)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_list_artifacts",
 		Description: "List all artifacts produced by a specific build.",
+		Annotations: readOnlyTool,
 	}, s.handleListArtifacts)
 
 	// ───────────────────────────────
 	// QUEUE
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_cancel_queue_item",
 		Description: "Cancel a queued build before it starts.",
+		Annotations: destructiveTool,
 	}, s.handleCancelQueueItem)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_wait_for_build",
+		Description: "Wait for a queued build to start and finish. Follows the queue item to its build, reports progress, and returns the final build with its result. If the wait times out, the build as last seen is returned with the error.",
+		Annotations: readOnlyTool,
+	}, s.handleWaitForBuild)
+
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_queue",
 		Description: "Get the current Jenkins build queue showing all pending builds.",
+		Annotations: readOnlyTool,
 	}, s.handleGetQueue)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_queue_item",
 		Description: "Get details about a specific queue item by ID.",
+		Annotations: readOnlyTool,
 	}, s.handleGetQueueItem)
 
 	// ───────────────────────────────
 	// VIEWS
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_create_view",
 		Description: "Create a new Jenkins view.",
+		Annotations: mutatingTool,
 	}, s.handleCreateView)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_view",
 		Description: "Get jobs in a specific Jenkins view.",
+		Annotations: readOnlyTool,
 	}, s.handleGetView)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_list_views",
 		Description: "List all Jenkins views.",
+		Annotations: readOnlyTool,
 	}, s.handleListViews)
 
 	// ───────────────────────────────
 	// SERVER
 	// ───────────────────────────────
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_list_instances",
+		Description: "List the Jenkins instances (controllers) this server can connect to. Includes response cache hit/miss counters when caching is enabled. Pass an instance name as the instance argument of any other tool to target it.",
+		Annotations: readOnlyTool,
+	}, s.handleListInstances)
+
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_server_health",
 		Description: "Get the health status of the Jenkins server.",
+		Annotations: readOnlyTool,
 	}, s.handleServerHealthStatus)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_list_nodes",
-		Description: "List all Jenkins nodes in the network.",
+		Description: "List all Jenkins nodes (agents and the built-in node) with their online state, offline cause, labels, monitor data (disk space, swap, response time), busy executors and the builds running on them.",
+		Annotations: readOnlyTool,
 	}, s.handleGetNodes)
 
-	mcp.AddTool(s.mcpServer, &mcp.Tool{
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_get_node",
+		Description: "Get the details of a single node: online state, offline cause, labels, monitor data (disk space, swap, response time), busy executors and the builds running on them.",
+		Annotations: readOnlyTool,
+	}, s.handleGetNode)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_take_node_offline",
+		Description: "Mark a node temporarily offline with a reason so no new builds start on it. Running builds are not interrupted. If the node is already offline, its reason is updated.",
+		Annotations: destructiveTool,
+	}, s.handleTakeNodeOffline)
+
+	addTool(s, &mcp.Tool{
+		Name:        "jenkins_bring_node_online",
+		Description: "Bring a node that was marked temporarily offline back online.",
+		Annotations: mutatingTool,
+	}, s.handleBringNodeOnline)
+
+	addTool(s, &mcp.Tool{
 		Name:        "jenkins_get_pipeline_script",
 		Description: "Retrieve the Jenkinsfile (pipeline script) of a pipeline job.",
+		Annotations: readOnlyTool,
 	}, s.handleGetPipelineScript)
 
 	s.log.WithFields(logrus.Fields{
-		"tool_count": 20,
-		"categories": []string{"jobs", "builds", "artifacts", "queue", "views", "server"},
+		"tool_count": s.toolCount,
+		"read_only":  s.config.Policy.ReadOnly,
+		"categories": []string{"jobs", "job-config", "multibranch", "builds", "pipeline", "tests", "artifacts", "queue", "views", "server"},
 	}).Info("Successfully registered all Jenkins tools")
 	return nil
 }
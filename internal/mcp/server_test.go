@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// newTestSession starts a server against a fake Jenkins serving handler and
+// returns a client session connected to it. Confirmation is off unless
+// configure enables it.
+func newTestSession(t *testing.T, handler http.Handler, configure func(*config.Config)) *mcp.ClientSession {
+	t.Helper()
+	return newTestSessionWithClient(t, handler, configure, nil)
+}
+
+// newTestSessionWithClient is newTestSession for a client with the given options
+func newTestSessionWithClient(t *testing.T, handler http.Handler, configure func(*config.Config), clientOptions *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+
+	jenkinsServer := httptest.NewServer(handler)
+	t.Cleanup(jenkinsServer.Close)
+
+	cfg := &config.Config{
+		JenkinsURL:   jenkinsServer.URL,
+		Username:     "admin",
+		Password:     "secret",
+		Timeout:      5 * time.Second,
+		Confirmation: config.ConfirmationConfig{Mode: config.ConfirmModeOff},
+	}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	go server.mcpServer.Run(ctx, serverTransport)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, clientOptions)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	return session
+}
+
+// callTool calls a tool and fails the test if the call itself fails
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) error = %v", name, err)
+	}
+	return result
+}
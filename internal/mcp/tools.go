@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/audit"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/metrics"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Annotations shared by tool registrations. Tools without ReadOnlyHint are
+// treated as mutating by the policy.
+var (
+	readOnlyTool    = &mcp.ToolAnnotations{ReadOnlyHint: true}
+	mutatingTool    = &mcp.ToolAnnotations{DestructiveHint: boolPtr(false)}
+	destructiveTool = &mcp.ToolAnnotations{DestructiveHint: boolPtr(true)}
+)
+
+// addTool registers a tool unless the policy excludes it, and wraps its
+// handler so that on every call the target Jenkins instance is selected, the
+// policy is checked again against it and configured tools are confirmed by the
+// client before they run. When Out is a concrete type its inferred schema is
+// published as the tool's output schema. Errors are returned to the client as
+// IsError results carrying their ErrorCode, and every call is recorded in the
+// tool metrics and traced as a span. Calls of mutating tools are also written
+// to the audit log, including calls the policy or confirmation stopped.
+func addTool[In, Out any](s *Server, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	if err := s.policy.AllowTool(tool); err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tool":   tool.Name,
+			"reason": err.Error(),
+		}).Debug("Tool not registered due to policy")
+		return
+	}
+
+	extra := make(map[string]*jsonschema.Schema)
+	if len(s.instanceNames) > 1 {
+		extra[instanceArgument] = s.instanceSchema()
+	}
+	if s.confirmer.AcceptsToken(tool.Name) {
+		extra[confirmTokenArgument] = confirmTokenSchema
+	}
+	if len(extra) > 0 {
+		withExtra, err := withExtraArguments[In](tool, extra)
+		if err != nil {
+			panic(fmt.Sprintf("tool %q: input schema: %v", tool.Name, err))
+		}
+		tool = withExtra
+	}
+
+	if tool.OutputSchema == nil && reflect.TypeFor[Out]() != reflect.TypeFor[any]() {
+		schema, err := outputSchema[Out]()
+		if err != nil {
+			panic(fmt.Sprintf("tool %q: output schema: %v", tool.Name, err))
+		}
+		withOutput := *tool
+		withOutput.OutputSchema = schema
+		tool = &withOutput
+	}
+
+	confirm := s.confirmer.Requires(tool.Name)
+
+	// call runs the tool and reports the outcome recorded in the audit log
+	call := func(ctx context.Context, request *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, string) {
+		instance, err := s.resolveInstance(request)
+		if err != nil {
+			return toolErrorResult(err), nil, audit.OutcomeError
+		}
+		ctx = withInstance(ctx, instance)
+		trace.SpanFromContext(ctx).SetAttributes(tracing.InstanceKey.String(instance.name))
+
+		if err := s.policy.CheckCall(ctx, tool, request, instance.client); err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tool":   tool.Name,
+				"reason": err.Error(),
+			}).Warn("Tool call rejected by policy")
+			return toolErrorResult(err), nil, audit.OutcomeDenied
+		}
+		s.registerSecretArguments(ctx, request)
+
+		if confirm {
+			result, err := s.confirmer.Confirm(ctx, tool, request)
+			if err != nil {
+				s.log.WithFields(logrus.Fields{
+					"tool":   tool.Name,
+					"reason": err.Error(),
+				}).Warn("Tool call not confirmed")
+				return toolErrorResult(err), nil, audit.OutcomeUnconfirmed
+			}
+			if result != nil {
+				return result, nil, audit.OutcomeConfirmationRequired
+			}
+		}
+
+		result, out, err := handler(ctx, request, args)
+		if err != nil {
+			return toolErrorResult(err), nil, audit.OutcomeError
+		}
+		return result, structuredOutput(out), audit.OutcomeSuccess
+	}
+
+	mcp.AddTool(s.mcpServer, tool, func(ctx context.Context, request *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		ctx, span := startToolSpan(ctx, tool, request)
+		defer span.End()
+
+		start := time.Now()
+		result, out, outcome := call(ctx, request, args)
+		duration := time.Since(start)
+		errorCode := resultErrorCode(result)
+		if errorCode != "" && outcome == audit.OutcomeSuccess {
+			outcome = audit.OutcomeError
+		}
+
+		metrics.ObserveToolCall(tool.Name, errorCode, duration)
+		endToolSpan(span, result, errorCode)
+		if !isReadOnly(tool) {
+			s.recordAudit(ctx, tool, request, result, out, outcome, errorCode, duration)
+		}
+		return result, out, nil
+	})
+	s.toolCount++
+}
+
+// withExtraArguments returns a copy of the tool whose input schema, inferred
+// from In, also accepts the given arguments
+func withExtraArguments[In any](tool *mcp.Tool, extra map[string]*jsonschema.Schema) (*mcp.Tool, error) {
+	schema, err := jsonschema.For[In](nil)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	for name, property := range extra {
+		schema.Properties[name] = property
+	}
+
+	t := *tool
+	t.InputSchema = schema
+	return &t, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startToolSpan starts the span of a tool call, annotated with the job and
+// build number named in its arguments. The Jenkins requests made by the
+// handler become its children.
+func startToolSpan(ctx context.Context, tool *mcp.Tool, request *mcp.CallToolRequest) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{tracing.ToolNameKey.String(tool.Name)}
+
+	if request != nil && request.Params != nil {
+		if jobNames := jobNamesFromArguments(request.Params.Arguments); len(jobNames) > 0 {
+			attrs = append(attrs, tracing.JobNameKey.String(jobNames[0]))
+		}
+		if buildNumber := buildNumberFromArguments(request.Params.Arguments); buildNumber > 0 {
+			attrs = append(attrs, tracing.BuildNumberKey.Int(buildNumber))
+		}
+	}
+
+	return tracing.Tracer().Start(ctx, "tools/call "+tool.Name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...))
+}
+
+// endToolSpan records the outcome of a tool call on its span
+func endToolSpan(span trace.Span, result *mcp.CallToolResult, errorCode string) {
+	if errorCode == "" {
+		return
+	}
+
+	description := resultText(result)
+	if description == "" {
+		description = errorCode
+	}
+	span.SetStatus(codes.Error, description)
+	span.SetAttributes(tracing.ErrorCodeKey.String(errorCode))
+}
+
+// buildNumberFromArguments returns the buildNumber argument of a tool call, or 0 when there is none
+func buildNumberFromArguments(raw json.RawMessage) int {
+	var args struct {
+		BuildNumber int `json:"buildNumber"`
+	}
+	if len(raw) == 0 || json.Unmarshal(raw, &args) != nil {
+		return 0
+	}
+	return args.BuildNumber
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// serveHTTP serves the given MCP handler over HTTP on the configured listen address.
+// When ctx is cancelled the listener stops accepting connections and in-flight
+// requests are given ShutdownTimeout to complete.
+func (s *Server) serveHTTP(ctx context.Context, handler http.Handler) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	httpServer := &http.Server{
+		Addr:              s.config.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"transport":   s.config.Transport,
+		"address":     s.config.ListenAddr,
+		"jenkins_url": s.config.JenkinsURL,
+		"instances":   s.instanceNames,
+	}).Info("Starting Jenkins MCP Server")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Error("MCP server failed")
+			return fmt.Errorf("MCP server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.log.WithField("timeout", s.config.ShutdownTimeout).Info("Shutting down MCP Server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		s.log.WithError(err).Error("MCP server shutdown failed")
+		return fmt.Errorf("MCP server shutdown failed: %w", err)
+	}
+
+	s.log.Info("MCP Server stopped gracefully")
+	return nil
+}
+
+// startMetrics binds the metrics listener and serves Prometheus metrics in the
+// background. The returned function shuts the listener down.
+func (s *Server) startMetrics() (func(), error) {
+	listener, err := net.Listen("tcp", s.config.Metrics.ListenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start metrics listener: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(s.config.Metrics.Path, metrics.Handler())
+
+	metricsServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"address": listener.Addr().String(),
+		"path":    s.config.Metrics.Path,
+	}).Info("Serving Prometheus metrics")
+
+	go func() {
+		if err := metricsServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Error("Metrics listener failed")
+		}
+	}()
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+		defer cancel()
+
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			s.log.WithError(err).Error("Metrics listener shutdown failed")
+		}
+	}, nil
+}
+
+// shutdownTimeout returns the configured graceful shutdown timeout, falling back to a sane default
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.config.ShutdownTimeout > 0 {
+		return s.config.ShutdownTimeout
+	}
+	return 10 * time.Second
+}
@@ -0,0 +1,110 @@
+// Package metrics defines the Prometheus metrics of the Jenkins MCP Server:
+// MCP tool calls and the HTTP traffic between the server and Jenkins.
+// Metrics are always recorded; they are only exposed when the metrics
+// listener is enabled.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "jenkins_mcp"
+
+// registry holds the server's metrics together with the Go runtime and process collectors
+var registry = prometheus.NewRegistry()
+
+var (
+	toolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "MCP tool calls, by tool.",
+	}, []string{"tool"})
+
+	toolErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_errors_total",
+		Help:      "MCP tool calls that returned an error, by tool and error code.",
+	}, []string{"tool", "code"})
+
+	toolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Duration of MCP tool calls, by tool.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"tool"})
+
+	jenkinsRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jenkins_requests_total",
+		Help:      "HTTP requests sent to Jenkins, by instance, endpoint, method and status code. Requests that got no response are counted with the code NETWORK_ERROR or TIMEOUT.",
+	}, []string{"instance", "endpoint", "method", "code"})
+
+	jenkinsDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "jenkins_request_duration_seconds",
+		Help:      "Duration of HTTP requests sent to Jenkins, by instance, endpoint and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"instance", "endpoint", "method"})
+
+	jenkinsRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jenkins_retries_total",
+		Help:      "Retried HTTP requests to Jenkins, by instance and endpoint.",
+	}, []string{"instance", "endpoint"})
+
+	crumbFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jenkins_crumb_failures_total",
+		Help:      "Failed CSRF crumb fetches, by instance.",
+	}, []string{"instance"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		toolCalls,
+		toolErrors,
+		toolDuration,
+		jenkinsRequests,
+		jenkinsDuration,
+		jenkinsRetries,
+		crumbFailures,
+	)
+}
+
+// Handler returns an HTTP handler that serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveToolCall records a finished MCP tool call. errorCode is empty when the call succeeded.
+func ObserveToolCall(tool, errorCode string, duration time.Duration) {
+	toolCalls.WithLabelValues(tool).Inc()
+	toolDuration.WithLabelValues(tool).Observe(duration.Seconds())
+	if errorCode != "" {
+		toolErrors.WithLabelValues(tool, errorCode).Inc()
+	}
+}
+
+// ObserveJenkinsRequest records a single HTTP request attempt to Jenkins.
+// code is the response status code, or the error code when no response was received.
+func ObserveJenkinsRequest(instance, endpoint, method, code string, duration time.Duration) {
+	jenkinsRequests.WithLabelValues(instance, endpoint, method, code).Inc()
+	jenkinsDuration.WithLabelValues(instance, endpoint, method).Observe(duration.Seconds())
+}
+
+// IncJenkinsRetry records that a request to Jenkins is being retried
+func IncJenkinsRetry(instance, endpoint string) {
+	jenkinsRetries.WithLabelValues(instance, endpoint).Inc()
+}
+
+// IncCrumbFailure records a failed CSRF crumb fetch
+func IncCrumbFailure(instance string) {
+	crumbFailures.WithLabelValues(instance).Inc()
+}
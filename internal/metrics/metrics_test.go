@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scrape returns the metrics exposition served by Handler
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestObserveToolCall(t *testing.T) {
+	ObserveToolCall("test_tool", "", 120*time.Millisecond)
+	ObserveToolCall("test_tool", "NOT_FOUND", 10*time.Millisecond)
+
+	body := scrape(t)
+	for _, want := range []string{
+		`jenkins_mcp_tool_calls_total{tool="test_tool"} 2`,
+		`jenkins_mcp_tool_errors_total{code="NOT_FOUND",tool="test_tool"} 1`,
+		`jenkins_mcp_tool_call_duration_seconds_count{tool="test_tool"} 2`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
+
+func TestObserveJenkinsRequest(t *testing.T) {
+	ObserveJenkinsRequest("test", "job", "GET", "200", 50*time.Millisecond)
+	ObserveJenkinsRequest("test", "job", "GET", "NETWORK_ERROR", time.Millisecond)
+	IncJenkinsRetry("test", "job")
+	IncCrumbFailure("test")
+
+	body := scrape(t)
+	for _, want := range []string{
+		`jenkins_mcp_jenkins_requests_total{code="200",endpoint="job",instance="test",method="GET"} 1`,
+		`jenkins_mcp_jenkins_requests_total{code="NETWORK_ERROR",endpoint="job",instance="test",method="GET"} 1`,
+		`jenkins_mcp_jenkins_request_duration_seconds_count{endpoint="job",instance="test",method="GET"} 2`,
+		`jenkins_mcp_jenkins_retries_total{endpoint="job",instance="test"} 1`,
+		`jenkins_mcp_jenkins_crumb_failures_total{instance="test"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
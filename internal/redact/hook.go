@@ -0,0 +1,68 @@
+package redact
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus hook that masks secrets in log messages and fields
+// before they are formatted. Fields with sensitive names, such as password
+// or token, are masked entirely.
+type Hook struct{}
+
+// Levels returns all log levels
+func (Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire masks the secrets in an entry. Field values are replaced, never
+// modified in place, since they may be shared with the caller.
+func (Hook) Fire(entry *logrus.Entry) error {
+	r := Default()
+	if !r.enabled {
+		return nil
+	}
+
+	entry.Message = r.String(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = redactField(r, key, value)
+	}
+	return nil
+}
+
+// redactField masks a single log field value
+func redactField(r *Redactor, key string, value any) any {
+	switch v := value.(type) {
+	case string:
+		return r.Value(key, v)
+	case map[string]string:
+		masked := make(map[string]string, len(v))
+		for name, s := range v {
+			masked[name] = r.Value(name, s)
+		}
+		return masked
+	case map[string]any:
+		masked := make(map[string]any, len(v))
+		for name, s := range v {
+			masked[name] = redactField(r, name, s)
+		}
+		return masked
+	case []string:
+		masked := make([]string, len(v))
+		for i, s := range v {
+			masked[i] = r.Value(key, s)
+		}
+		return masked
+	case error:
+		if message := r.String(v.Error()); message != v.Error() {
+			return errors.New(message)
+		}
+		return v
+	default:
+		if IsSensitiveName(key) && value != nil {
+			return Mask
+		}
+		return value
+	}
+}
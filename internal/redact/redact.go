@@ -0,0 +1,329 @@
+// Package redact masks secrets before they leave the Jenkins MCP Server:
+// in its log, in the audit log and in the build logs and artifacts returned
+// to MCP clients. Secrets are recognised by known token formats, by
+// configured regular expressions and by their value: the configured Jenkins
+// credentials and the values of password build parameters are registered
+// as secrets and masked wherever they appear.
+//
+// A package-level redactor is shared by all of the server's components, so
+// that a password parameter passed to one tool is also masked in the build
+// log another tool returns. It applies the built-in patterns until
+// Configure is called.
+package redact
+
+import (
+	"container/list"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+)
+
+// Mask replaces every redacted secret
+const Mask = "****"
+
+// minSecretLength is the length below which registered secret values are
+// ignored; masking every occurrence of a very short value would garble
+// unrelated output
+const minSecretLength = 4
+
+// maxSecrets bounds the number of secrets registered at runtime, such as
+// password parameter values, that are kept; the least recently registered
+// are evicted first. Configured credentials do not count towards it.
+const maxSecrets = 1024
+
+// builtinPatterns match well-known credential formats. When a pattern has a
+// capturing group only the first group is masked.
+var builtinPatterns = []string{
+	// PEM private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	// GitHub tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	`\bgithub_pat_[A-Za-z0-9_]{22,}\b`,
+	// GitLab personal access tokens
+	`\bglpat-[A-Za-z0-9_-]{20,}\b`,
+	// Slack tokens
+	`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`,
+	// AWS access key IDs
+	`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`,
+	// JSON Web Tokens
+	`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+	// Jenkins API tokens
+	`\b11[0-9a-f]{32}\b`,
+	// Authorization headers
+	`(?i)\bauthorization["']?\s*[:=]\s*["']?(?:bearer|basic|token)\s+([^\s"',;]+)`,
+	// Bearer and Basic credentials elsewhere, when the value is padded base64 or
+	// contains a digit, so that phrases such as "basic authentication" are kept
+	`(?i)\b(?:bearer|basic)\s+([A-Za-z0-9+/]{8,}={1,2}|[A-Za-z0-9._~+/-]{4,}[0-9][A-Za-z0-9._~+/-]{4,}=*)`,
+	// Passwords in URLs
+	`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`,
+	// Assignments such as password=..., "token": "..." or API_KEY: ...
+	`(?i)(?:password|passwd|pwd|secret|token|api[_-]?key)["']?\s*[:=]\s*["']?([^\s"',;&]{4,})`,
+}
+
+// sensitiveKeys are substrings of log field and parameter names whose values are always masked
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "authorization"}
+
+// Redactor masks secrets in text
+type Redactor struct {
+	enabled  bool
+	patterns []*regexp.Regexp
+
+	mu       sync.RWMutex
+	pinned   map[string]struct{}      // Configured credentials, never evicted
+	secrets  map[string]*list.Element // Secrets registered at runtime, such as password parameter values
+	lru      *list.List               // most recently registered at the front
+	replacer *strings.Replacer
+	stale    bool // The replacer must be rebuilt before the next use
+}
+
+// New creates a redactor with the built-in and the given patterns
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	r := &Redactor{
+		enabled: cfg.Enabled,
+		pinned:  make(map[string]struct{}),
+		secrets: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, pattern := range append(builtinPatterns, cfg.Patterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// AddSecret registers a secret value that is masked wherever it appears.
+// Only the maxSecrets most recently registered values are kept.
+func (r *Redactor) AddSecret(value string) {
+	if !r.enabled || len(value) < minSecretLength {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pinned[value]; ok {
+		return
+	}
+	if elem, ok := r.secrets[value]; ok {
+		r.lru.MoveToFront(elem)
+		return
+	}
+
+	r.secrets[value] = r.lru.PushFront(value)
+	for r.lru.Len() > maxSecrets {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.secrets, oldest.Value.(string))
+	}
+	r.stale = true
+}
+
+// pinSecret registers a secret value that is never evicted
+func (r *Redactor) pinSecret(value string) {
+	if !r.enabled || len(value) < minSecretLength {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.secrets[value]; ok {
+		r.lru.Remove(elem)
+		delete(r.secrets, value)
+	}
+	r.pinned[value] = struct{}{}
+	r.stale = true
+}
+
+// secretReplacer returns the replacer masking the registered secrets, or nil
+// when there are none. It is rebuilt on first use after secrets were added.
+func (r *Redactor) secretReplacer() *strings.Replacer {
+	r.mu.RLock()
+	replacer, stale := r.replacer, r.stale
+	r.mu.RUnlock()
+	if !stale {
+		return replacer
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stale {
+		return r.replacer
+	}
+
+	// Replace longer secrets first so that a secret containing another is masked whole
+	values := make([]string, 0, len(r.pinned)+len(r.secrets))
+	for secret := range r.pinned {
+		values = append(values, secret)
+	}
+	for secret := range r.secrets {
+		values = append(values, secret)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	oldnew := make([]string, 0, 2*len(values))
+	for _, secret := range values {
+		oldnew = append(oldnew, secret, Mask)
+	}
+	r.replacer = strings.NewReplacer(oldnew...)
+	r.stale = false
+	return r.replacer
+}
+
+// String masks the secrets in s
+func (r *Redactor) String(s string) string {
+	if !r.enabled || s == "" {
+		return s
+	}
+
+	if replacer := r.secretReplacer(); replacer != nil {
+		s = replacer.Replace(s)
+	}
+
+	for _, re := range r.patterns {
+		s = maskMatches(re, s)
+	}
+	return s
+}
+
+// Bytes masks the secrets in data when it is UTF-8 text; binary data is returned unchanged
+func (r *Redactor) Bytes(data []byte) []byte {
+	if !r.enabled || !utf8.Valid(data) {
+		return data
+	}
+	return []byte(r.String(string(data)))
+}
+
+// Value masks the value of a named field or parameter: entirely when the
+// name looks sensitive, otherwise the secrets within it
+func (r *Redactor) Value(name, value string) string {
+	if !r.enabled || value == "" {
+		return value
+	}
+	if IsSensitiveName(name) {
+		return Mask
+	}
+	return r.String(value)
+}
+
+// maskMatches replaces the matches of re in s with the mask. When re has a
+// capturing group only the text of the first group is replaced.
+func maskMatches(re *regexp.Regexp, s string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllLiteralString(s, Mask)
+	}
+
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[2], m[3]
+		if start < 0 {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(Mask)
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// IsSensitiveName reports whether a field or parameter name suggests its value is a secret
+func IsSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, key := range sensitiveKeys {
+		if strings.Contains(lower, key) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	stdMu sync.RWMutex
+	std   = mustNew(config.RedactionConfig{Enabled: true})
+)
+
+func mustNew(cfg config.RedactionConfig) *Redactor {
+	r, err := New(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Configure replaces the package-level redactor with one built from the
+// configuration and registers the configured Jenkins credentials and audit
+// webhook token as secrets
+func Configure(cfg *config.Config) error {
+	r, err := New(cfg.Redaction)
+	if err != nil {
+		return err
+	}
+
+	r.pinSecret(cfg.Password)
+	r.pinSecret(cfg.APIToken)
+	r.pinSecret(cfg.Audit.WebhookToken)
+	for _, instance := range cfg.Instances {
+		r.pinSecret(instance.Password)
+		r.pinSecret(instance.APIToken)
+	}
+
+	stdMu.Lock()
+	std = r
+	stdMu.Unlock()
+	return nil
+}
+
+// Default returns the package-level redactor
+func Default() *Redactor {
+	stdMu.RLock()
+	defer stdMu.RUnlock()
+	return std
+}
+
+// AddSecret registers a secret value with the package-level redactor
+func AddSecret(value string) {
+	Default().AddSecret(value)
+}
+
+// String masks the secrets in s using the package-level redactor
+func String(s string) string {
+	return Default().String(s)
+}
+
+// Bytes masks the secrets in UTF-8 data using the package-level redactor
+func Bytes(data []byte) []byte {
+	return Default().Bytes(data)
+}
+
+// Value masks a named value using the package-level redactor
+func Value(name, value string) string {
+	return Default().Value(name, value)
+}
+
+// Parameters returns a copy of a parameter map with secret values masked
+func Parameters(params map[string]string) map[string]string {
+	if params == nil {
+		return nil
+	}
+
+	r := Default()
+	masked := make(map[string]string, len(params))
+	for name, value := range params {
+		masked[name] = r.Value(name, value)
+	}
+	return masked
+}
@@ -0,0 +1,123 @@
+// Package tracing sets up OpenTelemetry tracing for the Jenkins MCP Server
+// and defines the span attributes shared by MCP tool calls and Jenkins
+// requests. When tracing is disabled the global no-op tracer provider is
+// used, so instrumented code does not need to check whether it is enabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer that creates the server's spans
+const instrumentationName = "github.com/NithishNithi/go-jenkins-mcp"
+
+// Span attributes describing MCP tool calls and the Jenkins objects they act on
+const (
+	ToolNameKey    = attribute.Key("mcp.tool.name")
+	InstanceKey    = attribute.Key("jenkins.instance")
+	JobNameKey     = attribute.Key("jenkins.job.name")
+	BuildNumberKey = attribute.Key("jenkins.build.number")
+	EndpointKey    = attribute.Key("jenkins.endpoint")
+	ErrorCodeKey   = attribute.Key("jenkins.error.code")
+	CacheHitKey    = attribute.Key("jenkins.cache.hit")
+)
+
+// Tracer returns the tracer used for all of the server's spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// RecordError marks the span as failed with the given error and error code
+func RecordError(span trace.Span, err error, code string) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	if code != "" {
+		span.SetAttributes(ErrorCodeKey.String(code))
+	}
+}
+
+// Setup installs a global tracer provider that exports spans as configured.
+// The returned function flushes and stops the exporter; it is a no-op when
+// tracing is disabled.
+func Setup(ctx context.Context, cfg config.TracingConfig, serviceName, serviceVersion string) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(serviceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// newExporter creates the span exporter selected by the configuration
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(cfg.Exporter) {
+	case config.TracingExporterStdout:
+		// stdout carries the MCP stdio transport, so spans go to stderr
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case "", config.TracingExporterOTLP:
+		if strings.ToLower(cfg.Protocol) == config.TracingProtocolHTTP {
+			var opts []otlptracehttp.Option
+			if strings.Contains(cfg.Endpoint, "://") {
+				opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+			} else if cfg.Endpoint != "" {
+				opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+			}
+			if cfg.Insecure {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			return otlptracehttp.New(ctx, opts...)
+		}
+
+		var opts []otlptracegrpc.Option
+		if strings.Contains(cfg.Endpoint, "://") {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		} else if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, errors.New("unsupported exporter")
+	}
+}
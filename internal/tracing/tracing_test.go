@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	t.Run("disabled", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{}, "test", "0.0.0")
+		if err != nil {
+			t.Fatalf("Setup() failed: %v", err)
+		}
+		if otel.GetTracerProvider() != previous {
+			t.Error("Setup() replaced the tracer provider although tracing is disabled")
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown() failed: %v", err)
+		}
+	})
+
+	t.Run("stdout exporter", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{
+			Enabled:     true,
+			Exporter:    config.TracingExporterStdout,
+			SampleRatio: 1,
+		}, "test", "0.0.0")
+		if err != nil {
+			t.Fatalf("Setup() failed: %v", err)
+		}
+		if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+			t.Errorf("tracer provider = %T, want the SDK provider", otel.GetTracerProvider())
+		}
+
+		_, span := Tracer().Start(context.Background(), "test")
+		span.End()
+		if err := shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown() failed: %v", err)
+		}
+	})
+
+	t.Run("unsupported exporter", func(t *testing.T) {
+		if _, err := Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "zipkin"}, "test", "0.0.0"); err == nil {
+			t.Error("Setup() succeeded, want an error for an unsupported exporter")
+		}
+	})
+}
+
+func TestRecordError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(context.Background(), "call")
+	RecordError(span, errors.New("job not found"), "NOT_FOUND")
+	span.End()
+
+	ended := recorder.Ended()[0]
+	if ended.Status().Code != codes.Error || ended.Status().Description != "job not found" {
+		t.Errorf("status = %v, want an error status with the message", ended.Status())
+	}
+	found := false
+	for _, kv := range ended.Attributes() {
+		if kv.Key == ErrorCodeKey && kv.Value.AsString() == "NOT_FOUND" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("attributes = %v, want %s=NOT_FOUND", ended.Attributes(), ErrorCodeKey)
+	}
+	if len(ended.Events()) != 1 {
+		t.Errorf("recorded %d events, want the exception event", len(ended.Events()))
+	}
+}
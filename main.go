@@ -6,10 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/NithishNithi/go-jenkins-mcp/internal/config"
 	"github.com/NithishNithi/go-jenkins-mcp/internal/mcp"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/redact"
+	"github.com/NithishNithi/go-jenkins-mcp/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -90,6 +95,9 @@ func main() {
 		TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
 	})
 
+	// Mask secrets in log messages and fields
+	log.AddHook(redact.Hook{})
+
 	log.WithFields(logrus.Fields{
 		"version": "1.0.0",
 		"service": "jenkins-mcp-server",
@@ -101,21 +109,62 @@ func main() {
 		log.WithError(err).Fatal("Failed to load configuration")
 	}
 
+	// Apply the configured redaction patterns and mask the Jenkins credentials
+	if err := redact.Configure(cfg); err != nil {
+		log.WithError(err).Fatal("Failed to configure redaction")
+	}
+
 	log.WithFields(logrus.Fields{
 		"jenkins_url": cfg.JenkinsURL,
 		"username":    cfg.Username,
 		"timeout":     cfg.Timeout,
+		"transport":   cfg.Transport,
+		"instance":    cfg.DefaultInstance(),
+		"instances":   len(cfg.Instances) + 1,
 	}).Info("Configuration loaded successfully")
 
+	// Set up tracing before any Jenkins request is made
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, "jenkins-mcp-server", "1.0.0")
+	if err != nil {
+		log.WithError(err).Fatal("Failed to set up tracing")
+	}
+	if cfg.Audit.Enabled() {
+		log.WithFields(logrus.Fields{
+			"file":    cfg.Audit.File,
+			"webhook": cfg.Audit.WebhookURL != "",
+		}).Info("Audit log enabled")
+	}
+	if cfg.Tracing.Enabled {
+		log.WithFields(logrus.Fields{
+			"exporter": cfg.Tracing.Exporter,
+			"endpoint": cfg.Tracing.Endpoint,
+		}).Info("Tracing enabled")
+	}
+
 	// Create MCP server
 	server, err := mcp.NewServer(cfg, log)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to create MCP server")
 	}
 
-	// Start the server with stdio communication
-	ctx := context.Background()
-	if err := server.Start(ctx); err != nil {
+	// Stop the server gracefully on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Start the server with the configured transport
+	err = server.Start(ctx)
+	if closeErr := server.Close(); closeErr != nil {
+		log.WithError(closeErr).Warn("Failed to close audit log")
+	}
+
+	// Flush buffered spans before exiting
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if flushErr := shutdownTracing(flushCtx); flushErr != nil {
+		log.WithError(flushErr).Warn("Failed to flush traces")
+	}
+
+	if err != nil {
 		log.WithError(err).Fatal("Server failed")
 	}
 }